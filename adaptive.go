@@ -0,0 +1,104 @@
+package errdecode
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// AdaptiveOrder is used to reorder the evaluation of rule matchers by their
+// observed hit frequency, improving the average latency of Translate when a
+// few classifications dominate the error distribution.
+//
+// Every interval matcher evaluations, matchers are sorted by hit count and
+// the counts are halved, so that the order follows shifts in the
+// distribution.
+//
+// Since the first matching rule wins, it should only be used when matchers
+// are mutually exclusive; in particular, a catch-all matcher would end up
+// shadowing every other matcher. Error values are still compared first.
+//
+// It reorders the matchers of the default encoder only: it has no effect on
+// decoders with a custom encoder, see Encoder and EncoderContext, whatever
+// the order of the options.
+func AdaptiveOrder(interval int) Option {
+	return func(d *Decoder) {
+		if !d.customEncoder {
+			d.encoder = newAdaptiveEncoder(d.idx, interval).withContext()
+		}
+		d.options = append(d.options, "AdaptiveOrder")
+	}
+}
+
+// adaptiveMatcher is a matcher that counts its hits.
+type adaptiveMatcher struct {
+	codedMatcher
+	hits atomic.Uint64
+}
+
+// adaptiveMatchers holds matchers in self-tuning order.
+type adaptiveMatchers struct {
+	mu       sync.Mutex
+	order    atomic.Value // []*adaptiveMatcher
+	evals    atomic.Uint64
+	interval uint64
+}
+
+func newAdaptiveEncoder(idx *ruleIndex, interval int) EncoderFunc {
+	if interval < 1 {
+		interval = 1
+	}
+	ms := make([]*adaptiveMatcher, len(idx.matchers))
+	for i, m := range idx.matchers {
+		ms[i] = &adaptiveMatcher{codedMatcher: m}
+	}
+	am := &adaptiveMatchers{interval: uint64(interval)}
+	am.order.Store(ms)
+
+	return func(err error) (int, string) {
+		if code, ok := idx.matchError(err); ok {
+			return code, idx.codeToRule[code].Message
+		}
+		code, ok := idx.matchTyped(err)
+		if !ok {
+			code, ok = am.match(err)
+		}
+		if !ok {
+			return 0, "" // unclassified error
+		}
+		return code, idx.codeToRule[code].Message
+	}
+}
+
+func (am *adaptiveMatchers) match(err error) (int, bool) {
+	for _, m := range am.order.Load().([]*adaptiveMatcher) {
+		if n := am.evals.Add(1); n%am.interval == 0 {
+			am.reorder()
+		}
+		if isMatch := m.match(err); isMatch {
+			m.hits.Add(1)
+			return m.code, true
+		}
+	}
+	return 0, false
+}
+
+// reorder sorts matchers by hit count and decays the counts.
+func (am *adaptiveMatchers) reorder() {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	current := am.order.Load().([]*adaptiveMatcher)
+	ms := make([]*adaptiveMatcher, len(current))
+	copy(ms, current)
+
+	hits := make(map[*adaptiveMatcher]uint64, len(ms))
+	for _, m := range ms {
+		hits[m] = m.hits.Load()
+	}
+	sort.SliceStable(ms, func(i, j int) bool { return hits[ms[i]] > hits[ms[j]] })
+	for _, m := range ms {
+		m.hits.Store(hits[m] / 2)
+	}
+	am.order.Store(ms)
+}
@@ -0,0 +1,83 @@
+package errdecode_test
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestAdaptiveOrder(t *testing.T) {
+	var rareEvals atomic.Int64
+	dec := errdecode.New([]errdecode.Rule{
+		{
+			Code:    1001,
+			Message: "error.rare",
+			Match: func(err error) bool {
+				rareEvals.Add(1)
+				return strings.HasPrefix(err.Error(), "rare")
+			},
+		},
+		{
+			Code:    1002,
+			Message: "error.frequent",
+			Match:   func(err error) bool { return strings.HasPrefix(err.Error(), "frequent") },
+		},
+		{Code: 1003, Message: "error.value", Errors: []error{errClient1}},
+	}, errdecode.AdaptiveOrder(10))
+
+	errFrequent := errors.New("frequent")
+	for i := 0; i < 100; i++ {
+		dec.Translate(errFrequent)
+	}
+	before := rareEvals.Load()
+	for i := 0; i < 100; i++ {
+		dec.Translate(errFrequent)
+	}
+	if evals := rareEvals.Load() - before; evals != 0 {
+		t.Fatalf("expected frequent matcher to be evaluated first, rare matcher ran %d times", evals)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"reordered matcher still classifies", errors.New("rare"), "error.rare"},
+		{"frequent matcher classifies", errFrequent, "error.frequent"},
+		{"error values are compared first", errClient1, "error.value"},
+		{"unclassified value is returned", errors.New("unmatched"), "unmatched"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if msg := dec.Translate(tt.err).Error(); msg != tt.want {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdaptiveOrderCustomEncoder(t *testing.T) {
+	rules := []errdecode.Rule{{Code: 1001, Message: "error.value", Errors: []error{errClient1}}}
+	enc := errdecode.Encoder(func(err error) (int, string) { return 1002, "error.custom" })
+
+	tests := []struct {
+		name    string
+		options []errdecode.Option
+	}{
+		{"encoder first", []errdecode.Option{enc, errdecode.AdaptiveOrder(10)}},
+		{"encoder last", []errdecode.Option{errdecode.AdaptiveOrder(10), enc}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := errdecode.New(rules, tt.options...)
+			if msg := dec.Translate(errClient1).Error(); msg != "error.custom" {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, "error.custom")
+			}
+		})
+	}
+}
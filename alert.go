@@ -0,0 +1,77 @@
+package errdecode
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Threshold describes the rate of errors of a code above which an alert
+// fires, e.g., more than 100 authentication failures per minute.
+type Threshold struct {
+	// Code is the code of the errors counted. Zero counts unclassified
+	// errors.
+	Code int
+
+	// Count is the number of errors within Window above which the alert
+	// fires.
+	Count int
+
+	// Window is the duration errors are counted over.
+	Window time.Duration
+}
+
+// AlertFunc is called when a threshold is exceeded, see OnThreshold, with the
+// start of the window and the number of errors counted so far.
+type AlertFunc func(ctx context.Context, t Threshold, start time.Time, count int)
+
+// OnThreshold is used to be alerted when the rate of errors of a code exceeds
+// t, see OnTranslate. It may be used several times, e.g., for several codes
+// or rates.
+//
+// Errors are counted over consecutive windows, the first of which starts
+// with the first error of the code. fn is called once per window, by the
+// translation exceeding the threshold, so it should be fast. Every decoder
+// built with the option, e.g., on every reload of a Watcher, counts its own
+// errors.
+func OnThreshold(t Threshold, fn AlertFunc) Option {
+	return func(d *Decoder) {
+		w := &alertWindow{threshold: t, fn: fn}
+		d.onTranslate = append(d.onTranslate, w.record)
+		d.options = append(d.options, "OnThreshold")
+	}
+}
+
+// alertWindow counts the errors of a threshold over the current window.
+type alertWindow struct {
+	threshold Threshold
+	fn        AlertFunc
+
+	mu    sync.Mutex
+	start time.Time
+	count int
+}
+
+func (w *alertWindow) record(ctx context.Context, err, translated error) {
+	code := 0
+	if ce, ok := translated.(ClassifiedError); ok {
+		code = ce.Code()
+	}
+	if code != w.threshold.Code {
+		return
+	}
+
+	now := time.Now()
+	w.mu.Lock()
+	if w.start.IsZero() || now.Sub(w.start) >= w.threshold.Window {
+		w.start, w.count = now, 0
+	}
+	w.count++
+	fire := w.count == w.threshold.Count+1
+	start, count := w.start, w.count
+	w.mu.Unlock()
+
+	if fire {
+		w.fn(ctx, w.threshold, start, count)
+	}
+}
@@ -0,0 +1,72 @@
+package errdecode_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestOnThreshold(t *testing.T) {
+	type alert struct {
+		code, count int
+	}
+	var alerts []alert
+	record := func(_ context.Context, th errdecode.Threshold, _ time.Time, count int) {
+		alerts = append(alerts, alert{th.Code, count})
+	}
+	window := 50 * time.Millisecond
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+		{Code: codeCustomError, Message: "error.custom", Errors: []error{errClient2}},
+	},
+		errdecode.OnThreshold(errdecode.Threshold{Code: codeClientError, Count: 2, Window: window}, record),
+		errdecode.OnThreshold(errdecode.Threshold{Code: 0, Count: 1, Window: time.Hour}, record),
+	)
+
+	for i := 0; i < 5; i++ {
+		dec.Translate(errClient1)
+		dec.Translate(errClient2)
+	}
+	dec.Translate(errUnclassified)
+	dec.Translate(errUnclassified)
+
+	want := []alert{{codeClientError, 3}, {0, 2}}
+	if len(alerts) != len(want) {
+		t.Fatalf("unexpected alerts: got=%v want=%v", alerts, want)
+	}
+	for i := range want {
+		if alerts[i] != want[i] {
+			t.Fatalf("unexpected alert %d: got=%v want=%v", i, alerts[i], want[i])
+		}
+	}
+
+	time.Sleep(window)
+	dec.Translate(errClient1)
+	dec.Translate(errClient1)
+	if len(alerts) != 2 {
+		t.Fatalf("unexpected alerts in new window: got=%v", alerts[2:])
+	}
+	dec.Translate(errClient1)
+	if len(alerts) != 3 {
+		t.Fatalf("unexpected alerts: got=%d want=3", len(alerts))
+	}
+}
+
+func TestOnThresholdPerDecoder(t *testing.T) {
+	var alerts int
+	threshold := errdecode.OnThreshold(errdecode.Threshold{Code: codeClientError, Count: 2, Window: time.Hour}, func(context.Context, errdecode.Threshold, time.Time, int) {
+		alerts++
+	})
+	rules := []errdecode.Rule{{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}}}
+
+	for i := 0; i < 2; i++ {
+		dec := errdecode.New(rules, threshold)
+		dec.Translate(errClient1)
+		dec.Translate(errClient1)
+	}
+	if alerts != 0 {
+		t.Fatalf("unexpected alerts: got=%d want=0", alerts)
+	}
+}
@@ -0,0 +1,18 @@
+// Command errdecode-vet runs the errdecode analyzers with go vet:
+//
+//	go install github.com/iamrgon/errdecode/analysis/cmd/errdecode-vet@latest
+//	go vet -vettool=$(which errdecode-vet) ./...
+package main
+
+import (
+	"github.com/iamrgon/errdecode/analysis/deadrules"
+	"github.com/iamrgon/errdecode/analysis/unclassified"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(
+		unclassified.Analyzer,
+		deadrules.Analyzer,
+	)
+}
@@ -0,0 +1,174 @@
+// Package deadrules defines an analyzer reporting errdecode rules whose
+// sentinel errors are never returned, i.e., codes that can no longer be
+// produced.
+//
+// A sentinel counts as returned when it is used anywhere other than in
+// comparisons (==, errors.Is, switch cases), rule Errors lists, and
+// errdecode.RegisterError calls. Usages are collected from the package
+// declaring the rules and its dependencies; sentinels only returned by
+// packages importing the rules package are not seen, so rule sets are best
+// declared next to the code they serve. Test files are ignored.
+package deadrules
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/iamrgon/errdecode/analysis/internal/typeutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer reports rule sentinels that are never returned.
+var Analyzer = &analysis.Analyzer{
+	Name:      "deadrules",
+	Doc:       "report errdecode rules whose sentinel errors are never returned",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(usedFact)},
+	Run:       run,
+}
+
+// usedFact lists the package-level error variables, as "path.Name", used
+// by a package.
+type usedFact struct {
+	Errors []string
+}
+
+func (*usedFact) AFact() {}
+
+func (f *usedFact) String() string { return "used(" + strings.Join(f.Errors, ", ") + ")" }
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	used := make(map[string]bool)
+	var rules []*ast.CompositeLit
+	insp.WithStack([]ast.Node{(*ast.Ident)(nil), (*ast.CompositeLit)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push || isTestFile(pass, n.Pos()) {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if typeutil.IsErrdecodeType(pass.TypesInfo.TypeOf(n), "Rule") {
+				rules = append(rules, n)
+			}
+		case *ast.Ident:
+			if obj := sentinel(pass, n); obj != nil && isReturn(pass, n, stack) {
+				used[key(obj)] = true
+			}
+		}
+		return true
+	})
+	if len(used) > 0 {
+		fact := &usedFact{Errors: make([]string, 0, len(used))}
+		for k := range used {
+			fact.Errors = append(fact.Errors, k)
+		}
+		sort.Strings(fact.Errors)
+		pass.ExportPackageFact(fact)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	for _, f := range pass.AllPackageFacts() {
+		for _, k := range f.Fact.(*usedFact).Errors {
+			used[k] = true
+		}
+	}
+	for _, lit := range rules {
+		errs, ok := typeutil.RuleErrors(lit).(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		dead := 0
+		for _, elt := range errs.Elts {
+			id := ident(elt)
+			if id == nil {
+				continue
+			}
+			if obj := sentinel(pass, id); obj != nil && !used[key(obj)] {
+				pass.Reportf(elt.Pos(), "%s.%s is never returned", obj.Pkg().Name(), obj.Name())
+				dead++
+			}
+		}
+		if dead > 0 && dead == len(errs.Elts) {
+			pass.Reportf(lit.Pos(), "rule%s is dead: none of its errors is returned", code(pass, lit))
+		}
+	}
+	return nil, nil
+}
+
+// sentinel returns the package-level error variable referenced by id.
+func sentinel(pass *analysis.Pass, id *ast.Ident) *types.Var {
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() || !typeutil.IsError(v.Type()) {
+		return nil
+	}
+	return v
+}
+
+// isReturn reports whether the reference id, whose ancestors are stack, may
+// produce the error rather than inspect it.
+func isReturn(pass *analysis.Pass, id *ast.Ident, stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.SelectorExpr, *ast.ParenExpr:
+			continue
+		case *ast.BinaryExpr:
+			return n.Op != token.EQL && n.Op != token.NEQ
+		case *ast.CaseClause:
+			return false
+		case *ast.CallExpr:
+			fn := typeutil.CalledFunc(pass.TypesInfo, n)
+			return !typeutil.IsFunc(fn, "errors", "Is") && !typeutil.IsFunc(fn, typeutil.ErrdecodePath, "RegisterError")
+		case *ast.CompositeLit:
+			if i > 1 {
+				if kv, ok := stack[i-1].(*ast.KeyValueExpr); ok {
+					if lit, ok := stack[i-2].(*ast.CompositeLit); ok && typeutil.IsErrdecodeType(pass.TypesInfo.TypeOf(lit), "Rule") && typeutil.RuleErrors(lit) == ast.Expr(kv.Value) {
+						return false
+					}
+				}
+			}
+			return true
+		default:
+			return true
+		}
+	}
+	return true
+}
+
+func ident(expr ast.Expr) *ast.Ident {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return e.Sel
+	}
+	return nil
+}
+
+func key(obj types.Object) string {
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// code formats the constant code of a rule literal, if any.
+func code(pass *analysis.Pass, lit *ast.CompositeLit) string {
+	expr := typeutil.RuleCode(lit)
+	if expr == nil {
+		return ""
+	}
+	if tv := pass.TypesInfo.Types[expr]; tv.Value != nil && tv.Value.Kind() == constant.Int {
+		return " " + tv.Value.ExactString()
+	}
+	return ""
+}
+
+func isTestFile(pass *analysis.Pass, pos token.Pos) bool {
+	return strings.HasSuffix(pass.Fset.File(pos).Name(), "_test.go")
+}
@@ -0,0 +1,12 @@
+package deadrules_test
+
+import (
+	"testing"
+
+	"github.com/iamrgon/errdecode/analysis/deadrules"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), deadrules.Analyzer, "example.com/app/api")
+}
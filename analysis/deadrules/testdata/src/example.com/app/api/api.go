@@ -0,0 +1,17 @@
+package api
+
+import (
+	"example.com/app/domain"
+	"github.com/iamrgon/errdecode"
+)
+
+var Rules = []errdecode.Rule{
+	{Code: 1001, Errors: []error{domain.ErrNotFound, domain.ErrWrapped}},
+	{Code: 1002, Errors: []error{domain.ErrConflict, domain.ErrGone}},   // want `domain.ErrGone is never returned`
+	{Code: 1003, Errors: []error{domain.ErrLegacy, domain.ErrCompared}}, // want `domain.ErrLegacy is never returned` `domain.ErrCompared is never returned` `rule 1003 is dead: none of its errors is returned`
+	{Code: 1004, Errors: []error{domain.ErrSwitched}},                   // want `domain.ErrSwitched is never returned` `rule 1004 is dead: none of its errors is returned`
+}
+
+func init() {
+	errdecode.RegisterError("domain.legacy", domain.ErrLegacy)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+	ErrGone     = errors.New("gone")
+	ErrLegacy   = errors.New("legacy")
+	ErrCompared = errors.New("compared")
+	ErrSwitched = errors.New("switched")
+	ErrWrapped  = errors.New("wrapped")
+)
+
+func Find(id string) error {
+	if id == "" {
+		return fmt.Errorf("find: %w", ErrWrapped)
+	}
+	return ErrNotFound
+}
+
+func Save(id string) (err error) {
+	err = ErrConflict
+	return err
+}
+
+func Check(err error) bool {
+	switch err {
+	case ErrSwitched:
+		return true
+	}
+	return err == ErrCompared || errors.Is(err, ErrGone)
+}
@@ -0,0 +1,71 @@
+// Package typeutil provides helpers shared by the errdecode analyzers.
+package typeutil
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// ErrdecodePath is the import path of the errdecode package.
+const ErrdecodePath = "github.com/iamrgon/errdecode"
+
+// CalledFunc returns the function or method called by call, or nil if the
+// callee is not statically known.
+func CalledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[id].(*types.Func)
+	return fn
+}
+
+// IsFunc reports whether fn is the function or method name of package path.
+func IsFunc(fn *types.Func, path, name string) bool {
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == path && fn.Name() == name
+}
+
+// IsErrdecodeType reports whether t is the named errdecode type name.
+func IsErrdecodeType(t types.Type, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == ErrdecodePath && obj.Name() == name
+}
+
+// IsError reports whether t is the error type.
+func IsError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// RuleErrors returns the expression of the Errors field of a Rule literal, or
+// nil if the field is not set.
+func RuleErrors(lit *ast.CompositeLit) ast.Expr {
+	return ruleField(lit, "Errors")
+}
+
+// RuleCode returns the expression of the Code field of a Rule literal, or nil
+// if the field is not set.
+func RuleCode(lit *ast.CompositeLit) ast.Expr {
+	return ruleField(lit, "Code")
+}
+
+func ruleField(lit *ast.CompositeLit, name string) ast.Expr {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == name {
+			return kv.Value
+		}
+	}
+	return nil
+}
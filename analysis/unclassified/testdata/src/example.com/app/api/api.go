@@ -0,0 +1,39 @@
+package api
+
+import (
+	"example.com/app/domain"
+	"github.com/iamrgon/errdecode"
+)
+
+func init() {
+	errdecode.RegisterError("domain.registered", domain.ErrRegistered)
+}
+
+var dec = errdecode.New([]errdecode.Rule{
+	{Code: 1001, Message: "error.not_found", Errors: []error{domain.ErrNotFound}}, // want `sentinel error domain.ErrConflict is not classified by any rule` `sentinel error domain.errWrapped is not classified by any rule`
+})
+
+func HandleGet(id string) error {
+	if id == "" {
+		return nil
+	}
+	if err := domain.Find(id); err != nil {
+		return dec.Translate(err)
+	}
+	err := domain.Find(id)
+	translated := dec.Translate(err)
+	if translated != nil {
+		return translated
+	}
+	return err // want `error returned by handler HandleGet is not classified by an errdecode.Decoder`
+}
+
+func DeleteHandler(id string) (int, error) {
+	return 0, domain.Find(id) // want `error returned by handler DeleteHandler is not classified by an errdecode.Decoder`
+}
+
+func helper(id string) error {
+	return domain.Find(id)
+}
+
+func HandleNothing() {}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNotFound = errors.New("not found") // want ErrNotFound:"sentinel"
+
+var ErrConflict = fmt.Errorf("conflict") // want ErrConflict:"sentinel"
+
+var ErrRegistered = errors.New("registered") // want ErrRegistered:"sentinel"
+
+var errWrapped = fmt.Errorf("wrapped: %w", ErrNotFound) // want errWrapped:"sentinel"
+
+var notAnError = "not an error"
+
+func Find(id string) error {
+	if id == "" {
+		return errWrapped
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,16 @@
+// Package errdecode is a stub of the errdecode API used by the tests.
+package errdecode
+
+type Rule struct {
+	Code    int
+	Message string
+	Errors  []error
+}
+
+type Decoder struct{}
+
+func New(rs []Rule) *Decoder { return &Decoder{} }
+
+func (d *Decoder) Translate(err error) error { return err }
+
+func RegisterError(name string, err error) {}
@@ -0,0 +1,257 @@
+// Package unclassified defines an analyzer reporting classification gaps:
+// handler functions returning errors that were never translated by an
+// errdecode.Decoder, and sentinel errors that no errdecode.Rule classifies.
+//
+// The analyzer can be run with go vet, see the errdecode-vet command.
+package unclassified
+
+import (
+	"go/ast"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iamrgon/errdecode/analysis/internal/typeutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer reports unclassified error returns and sentinels.
+var Analyzer = &analysis.Analyzer{
+	Name:      "unclassified",
+	Doc:       "report errors returned by handlers without being classified, and sentinel errors missing from rules",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(sentinelFact)},
+	Run:       run,
+}
+
+var (
+	handlers = `^(Handle|Serve)|Handler$`
+	module   string
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&handlers, "handlers", handlers, "regexp matching the names of handler functions")
+	Analyzer.Flags.StringVar(&module, "module", "", "import path prefix of the packages whose sentinels must be classified (default: the enclosing module path)")
+}
+
+// sentinelFact marks a package-level error variable initialized with
+// errors.New or fmt.Errorf.
+type sentinelFact struct{}
+
+func (*sentinelFact) AFact()         {}
+func (*sentinelFact) String() string { return "sentinel" }
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	re, err := regexp.Compile(handlers)
+	if err != nil {
+		return nil, err
+	}
+	exportSentinels(pass)
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		fn := n.(*ast.FuncDecl)
+		if fn.Body != nil && re.MatchString(fn.Name.Name) && returnsError(pass, fn) {
+			checkHandler(pass, fn)
+		}
+	})
+	checkSentinels(pass, insp)
+	return nil, nil
+}
+
+// exportSentinels marks the sentinel errors declared by the package.
+func exportSentinels(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok || len(vs.Names) != len(vs.Values) {
+					continue
+				}
+				for i, name := range vs.Names {
+					obj, ok := pass.TypesInfo.Defs[name].(*types.Var)
+					if ok && typeutil.IsError(obj.Type()) && isSentinelInit(pass, vs.Values[i]) {
+						pass.ExportObjectFact(obj, new(sentinelFact))
+					}
+				}
+			}
+		}
+	}
+}
+
+func isSentinelInit(pass *analysis.Pass, expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn := typeutil.CalledFunc(pass.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil {
+		return false
+	}
+	switch fn.Pkg().Path() + "." + fn.Name() {
+	case "errors.New", "fmt.Errorf":
+		return true
+	}
+	return false
+}
+
+// checkHandler reports returned errors that were not translated.
+func checkHandler(pass *analysis.Pass, fn *ast.FuncDecl) {
+	translated := make(map[types.Object]bool)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok && isTranslation(pass, n.Rhs[i]) {
+						translated[objectOf(pass, id)] = true
+					}
+				}
+			}
+		case *ast.ReturnStmt:
+			if len(n.Results) == 0 {
+				return true
+			}
+			res := n.Results[len(n.Results)-1]
+			if isNil(pass, res) || isTranslation(pass, res) {
+				return true
+			}
+			if id, ok := res.(*ast.Ident); ok && translated[objectOf(pass, id)] {
+				return true
+			}
+			pass.Reportf(res.Pos(), "error returned by handler %s is not classified by an errdecode.Decoder", fn.Name.Name)
+		}
+		return true
+	})
+}
+
+// checkSentinels reports sentinel errors of the module that are not
+// classified by any rule declared in the package. Packages declaring no rules
+// are skipped.
+func checkSentinels(pass *analysis.Pass, insp *inspector.Inspector) {
+	var first *ast.CompositeLit
+	classified := make(map[types.Object]bool)
+	insp.Preorder([]ast.Node{(*ast.CompositeLit)(nil), (*ast.CallExpr)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if !typeutil.IsErrdecodeType(pass.TypesInfo.TypeOf(n), "Rule") {
+				return
+			}
+			if first == nil {
+				first = n
+			}
+			if errs := typeutil.RuleErrors(n); errs != nil {
+				markReferences(pass, errs, classified)
+			}
+		case *ast.CallExpr:
+			if typeutil.IsFunc(typeutil.CalledFunc(pass.TypesInfo, n), typeutil.ErrdecodePath, "RegisterError") {
+				for _, arg := range n.Args {
+					markReferences(pass, arg, classified)
+				}
+			}
+		}
+	})
+	if first == nil {
+		return
+	}
+
+	prefix := module
+	if prefix == "" {
+		prefix = modulePrefix(pass)
+	}
+	var missing []types.Object
+	for _, f := range pass.AllObjectFacts() {
+		if _, ok := f.Fact.(*sentinelFact); !ok || classified[f.Object] {
+			continue
+		}
+		pkg := f.Object.Pkg().Path()
+		if pkg == typeutil.ErrdecodePath {
+			continue
+		}
+		if pkg == prefix || strings.HasPrefix(pkg, prefix+"/") {
+			missing = append(missing, f.Object)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		a, b := missing[i], missing[j]
+		if a.Pkg().Path() != b.Pkg().Path() {
+			return a.Pkg().Path() < b.Pkg().Path()
+		}
+		return a.Name() < b.Name()
+	})
+	for _, obj := range missing {
+		pass.Reportf(first.Pos(), "sentinel error %s.%s is not classified by any rule", obj.Pkg().Name(), obj.Name())
+	}
+}
+
+func markReferences(pass *analysis.Pass, expr ast.Expr, into map[types.Object]bool) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if obj := pass.TypesInfo.Uses[id]; obj != nil {
+				into[obj] = true
+			}
+		}
+		return true
+	})
+}
+
+// isTranslation reports whether expr is a call returning a classified error,
+// e.g., Decoder.Translate.
+func isTranslation(pass *analysis.Pass, expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn := typeutil.CalledFunc(pass.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != typeutil.ErrdecodePath {
+		return false
+	}
+	switch fn.Name() {
+	case "Translate", "TranslateContext", "NewError", "WrapError", "Classify":
+		return true
+	}
+	return false
+}
+
+func returnsError(pass *analysis.Pass, fn *ast.FuncDecl) bool {
+	obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	results := obj.Type().(*types.Signature).Results()
+	return results.Len() > 0 && typeutil.IsError(results.At(results.Len()-1).Type())
+}
+
+func isNil(pass *analysis.Pass, expr ast.Expr) bool {
+	return pass.TypesInfo.Types[expr].IsNil()
+}
+
+func objectOf(pass *analysis.Pass, id *ast.Ident) types.Object {
+	if obj := pass.TypesInfo.Defs[id]; obj != nil {
+		return obj
+	}
+	return pass.TypesInfo.Uses[id]
+}
+
+// modulePrefix returns the path of the module enclosing the package. Drivers
+// without module information fall back to the first element of the import
+// path, e.g., "example.com" for "example.com/app/api".
+func modulePrefix(pass *analysis.Pass) string {
+	if pass.Module != nil && pass.Module.Path != "" {
+		return pass.Module.Path
+	}
+	path := pass.Pkg.Path()
+	if i := strings.Index(path, "/"); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
@@ -0,0 +1,12 @@
+package unclassified_test
+
+import (
+	"testing"
+
+	"github.com/iamrgon/errdecode/analysis/unclassified"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), unclassified.Analyzer, "example.com/app/api")
+}
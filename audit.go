@@ -0,0 +1,122 @@
+package errdecode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditRecord is a line of the audit trail of a decoder, see AuditTrail.
+type AuditRecord struct {
+	Time time.Time `json:"time"`
+
+	// CorrelationID is the correlation ID of the context passed to
+	// TranslateContext, if any, see WithCorrelationID.
+	CorrelationID string `json:"correlation_id,omitempty"`
+
+	// Code is the code of the classification, zero for unclassified errors.
+	Code int `json:"code"`
+
+	// Message is the translated message, i.e., what was exposed. It is empty
+	// for unclassified errors.
+	Message string `json:"message,omitempty"`
+
+	// ErrorType is the Go type of the innermost error of the translated
+	// error, e.g., "*pq.Error".
+	ErrorType string `json:"error_type"`
+
+	// Caller is the location of the code translating the error, as
+	// "file:line". Frames of errdecode packages, e.g., httpdecode, are
+	// skipped.
+	Caller string `json:"caller,omitempty"`
+}
+
+// AuditTrail is used to record every translation made by Translate and
+// TranslateContext to w, as JSON lines of AuditRecord, e.g., to review which
+// error details were exposed to whom. Writes are serialized, so w needs not
+// be safe for concurrent use.
+//
+// Errors writing to w are dropped, so that auditing never fails
+// translations. Wrap w to handle them.
+func AuditTrail(w io.Writer) Option {
+	a := &auditWriter{enc: json.NewEncoder(w)}
+	return func(d *Decoder) {
+		d.onTranslate = append(d.onTranslate, a.record)
+		d.options = append(d.options, "AuditTrail")
+	}
+}
+
+type auditWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (a *auditWriter) record(ctx context.Context, err, translated error) {
+	rec := AuditRecord{
+		Time:      time.Now(),
+		ErrorType: fmt.Sprintf("%T", innermost(err)),
+		Caller:    caller(),
+	}
+	rec.CorrelationID, _ = CorrelationIDFromContext(ctx)
+	if ce, ok := translated.(ClassifiedError); ok {
+		rec.Code, rec.Message = ce.Code(), ce.Error()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_ = a.enc.Encode(rec)
+}
+
+// innermost returns the last error of the wrap chain of err.
+func innermost(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// caller returns the location of the first caller outside of this module,
+// e.g., of the handler whose error httpdecode translated. Frames of test
+// packages are callers.
+func caller() string {
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if !internalFrame(frame.Function) {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// internalFrame reports whether the function fn, as named by runtime.Frame,
+// belongs to a package of this module other than a test package.
+func internalFrame(fn string) bool {
+	const module = "github.com/iamrgon/errdecode"
+	if !strings.HasPrefix(fn, module) {
+		return false
+	}
+	// The package path ends at the first dot after the last slash.
+	pkg := fn
+	if i := strings.LastIndexByte(pkg, '/'); i >= 0 {
+		if j := strings.IndexByte(pkg[i:], '.'); j >= 0 {
+			pkg = pkg[:i+j]
+		}
+	}
+	if pkg != module && !strings.HasPrefix(pkg, module+"/") {
+		return false
+	}
+	return !strings.HasSuffix(pkg, "_test")
+}
@@ -0,0 +1,66 @@
+package errdecode_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/httpdecode"
+)
+
+func TestAuditTrail(t *testing.T) {
+	var buf bytes.Buffer
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+	}, errdecode.AuditTrail(&buf))
+
+	ctx := errdecode.WithCorrelationID(context.Background(), "req-1")
+	dec.TranslateContext(ctx, errClient1)
+	dec.Translate(fmt.Errorf("load: %w", newCustomError("config not found")))
+
+	want := []errdecode.AuditRecord{
+		{CorrelationID: "req-1", Code: codeClientError, Message: "error.client", ErrorType: "*errors.errorString"},
+		{ErrorType: "*errdecode_test.CustomError"},
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("unexpected records: got=%d want=%d", len(lines), len(want))
+	}
+	for i, line := range lines {
+		var got errdecode.AuditRecord
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.CorrelationID != want[i].CorrelationID || got.Code != want[i].Code || got.Message != want[i].Message || got.ErrorType != want[i].ErrorType {
+			t.Fatalf("unexpected record %d: got=%+v want=%+v", i, got, want[i])
+		}
+		if got.Time.IsZero() {
+			t.Fatalf("unexpected zero time of record %d", i)
+		}
+		if file, _, _ := strings.Cut(got.Caller, ":"); filepath.Base(file) != "audit_test.go" {
+			t.Fatalf("unexpected caller of record %d: got=%s", i, got.Caller)
+		}
+	}
+}
+
+func TestAuditTrailCallerOutsideModule(t *testing.T) {
+	var buf bytes.Buffer
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+	}, errdecode.AuditTrail(&buf))
+
+	httpdecode.New(dec).Response(context.Background(), errClient1)
+
+	var got errdecode.AuditRecord
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file, _, _ := strings.Cut(got.Caller, ":"); filepath.Base(file) != "audit_test.go" {
+		t.Fatalf("unexpected caller: got=%s", got.Caller)
+	}
+}
@@ -0,0 +1,239 @@
+package errdecode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog is the serialized form of a rule set. Error values and matchers are
+// referenced by their registered names, see RegisterError and
+// RegisterMatcher.
+//
+// The schema is documented by catalog.schema.json. Catalogs are usually
+// loaded straight into rules, see LoadYAML, LoadJSON and LoadTOML; reading
+// the Catalog itself is useful for tooling that inspects catalogs without
+// registering anything.
+type Catalog struct {
+	Rules []CatalogRule `json:"rules" yaml:"rules" toml:"rules"`
+}
+
+// CatalogRule is the serialized form of a Rule.
+type CatalogRule struct {
+	// Name is an optional identifier, e.g., for generated constants.
+	Name string `json:"name,omitempty" yaml:"name,omitempty" toml:"name,omitempty"`
+
+	Code       int      `json:"code" yaml:"code" toml:"code"`
+	Message    string   `json:"message" yaml:"message" toml:"message"`
+	Errors     []string `json:"errors,omitempty" yaml:"errors,omitempty" toml:"errors,omitempty"`
+	Matcher    string   `json:"matcher,omitempty" yaml:"matcher,omitempty" toml:"matcher,omitempty"`
+	Retryable  bool     `json:"retryable,omitempty" yaml:"retryable,omitempty" toml:"retryable,omitempty"`
+	RetryAfter string   `json:"retry_after,omitempty" yaml:"retry_after,omitempty" toml:"retry_after,omitempty"`
+	Severity   string   `json:"severity,omitempty" yaml:"severity,omitempty" toml:"severity,omitempty"`
+	Priority   int      `json:"priority,omitempty" yaml:"priority,omitempty" toml:"priority,omitempty"`
+	HTTPStatus int      `json:"http_status,omitempty" yaml:"http_status,omitempty" toml:"http_status,omitempty"`
+	GRPCCode   int      `json:"grpc_code,omitempty" yaml:"grpc_code,omitempty" toml:"grpc_code,omitempty"`
+	Tags       []string `json:"tags,omitempty" yaml:"tags,omitempty" toml:"tags,omitempty"`
+	DocsURL    string   `json:"docs_url,omitempty" yaml:"docs_url,omitempty" toml:"docs_url,omitempty"`
+	Namespace  string   `json:"namespace,omitempty" yaml:"namespace,omitempty" toml:"namespace,omitempty"`
+	Deprecated bool     `json:"deprecated,omitempty" yaml:"deprecated,omitempty" toml:"deprecated,omitempty"`
+	ReplacedBy int      `json:"replaced_by,omitempty" yaml:"replaced_by,omitempty" toml:"replaced_by,omitempty"`
+}
+
+// ReadCatalog reads a catalog in the format given by a file extension, i.e.,
+// ".yaml" or ".yml", ".json" or ".toml". Unknown keys are rejected.
+func ReadCatalog(r io.Reader, ext string) (*Catalog, error) {
+	var c Catalog
+	switch ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(true)
+		if err := dec.Decode(&c); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("errdecode: decode YAML catalog: %w", err)
+		}
+	case ".json":
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&c); err != nil {
+			return nil, fmt.Errorf("errdecode: decode JSON catalog: %w", err)
+		}
+	case ".toml":
+		md, err := toml.NewDecoder(r).Decode(&c)
+		if err != nil {
+			return nil, fmt.Errorf("errdecode: decode TOML catalog: %w", err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			keys := make([]string, len(undecoded))
+			for i, k := range undecoded {
+				keys[i] = k.String()
+			}
+			return nil, fmt.Errorf("errdecode: decode TOML catalog: unknown keys %s", strings.Join(keys, ", "))
+		}
+	default:
+		return nil, fmt.Errorf("errdecode: unsupported catalog format %q", ext)
+	}
+	return &c, nil
+}
+
+// LoadYAML reads a rule catalog from YAML, so the catalog can be edited
+// without touching Go source:
+//
+//	rules:
+//	  - code: 1001
+//	    message: error.auth
+//	    errors: [auth.invalid_token, auth.missing_token]
+//	    severity: warning
+//	  - code: 1002
+//	    message: error.unavailable
+//	    matcher: net.timeout
+//	    retryable: true
+//	    http_status: 503
+//	    tags: [infrastructure]
+//
+// Unknown keys are rejected. Referenced error values and matchers must have
+// been registered beforehand, see RegisterError and RegisterMatcher.
+func LoadYAML(r io.Reader) ([]Rule, error) {
+	return loadCatalog(r, ".yaml")
+}
+
+// LoadJSON reads a rule catalog from JSON. The schema is the same as for
+// LoadYAML and is documented by catalog.schema.json:
+//
+//	{
+//		"rules": [
+//			{"code": 1001, "message": "error.auth", "errors": ["auth.invalid_token"], "http_status": 401},
+//			{"code": 1002, "message": "error.unavailable", "matcher": "net.timeout", "retryable": true}
+//		]
+//	}
+//
+// Unknown keys are rejected. Referenced error values and matchers must have
+// been registered beforehand, see RegisterError and RegisterMatcher.
+func LoadJSON(r io.Reader) ([]Rule, error) {
+	return loadCatalog(r, ".json")
+}
+
+// LoadTOML reads a rule catalog from TOML. The schema is the same as for
+// LoadYAML and is documented by catalog.schema.json:
+//
+//	[[rules]]
+//	code = 1001
+//	message = "error.auth"
+//	errors = ["auth.invalid_token"]
+//	http_status = 401
+//
+// Unknown keys are rejected. Referenced error values and matchers must have
+// been registered beforehand, see RegisterError and RegisterMatcher.
+func LoadTOML(r io.Reader) ([]Rule, error) {
+	return loadCatalog(r, ".toml")
+}
+
+func loadCatalog(r io.Reader, ext string) ([]Rule, error) {
+	c, err := ReadCatalog(r, ext)
+	if err != nil {
+		return nil, err
+	}
+	return c.Compile()
+}
+
+// Validate returns the problems of the catalog that do not depend on
+// registered names: duplicate codes, see ErrDuplicateCode, and names, and
+// illegal HTTP statuses, gRPC codes, retry delays and severities. Compile
+// rejects catalogs with problems, and tooling may report them all.
+func (c *Catalog) Validate() []error {
+	var errs []error
+	codes := make(map[int]bool)
+	names := make(map[string]int)
+	for _, cr := range c.Rules {
+		invalid := func(err error) { errs = append(errs, &ruleError{cr.Code, err}) }
+		if codes[cr.Code] {
+			invalid(ErrDuplicateCode)
+		}
+		codes[cr.Code] = true
+		if cr.Name != "" {
+			if prev, dup := names[cr.Name]; dup {
+				invalid(fmt.Errorf("name %q already used by rule %d", cr.Name, prev))
+			}
+			names[cr.Name] = cr.Code
+		}
+		if s := cr.HTTPStatus; s != 0 && (s < 100 || s > 599) {
+			invalid(fmt.Errorf("invalid HTTP status %d", s))
+		}
+		if cr.RetryAfter != "" {
+			if d, err := time.ParseDuration(cr.RetryAfter); err != nil || d < 0 {
+				invalid(fmt.Errorf("invalid retry_after %q", cr.RetryAfter))
+			}
+		}
+		if g := cr.GRPCCode; g < 0 || g > 16 {
+			invalid(fmt.Errorf("invalid gRPC code %d", g))
+		}
+		var severity Severity
+		if err := severity.UnmarshalText([]byte(cr.Severity)); err != nil {
+			invalid(err)
+		}
+	}
+	return errs
+}
+
+// ruleError reports a problem with a rule of a catalog.
+type ruleError struct {
+	code int
+	err  error
+}
+
+func (e *ruleError) Error() string {
+	return fmt.Sprintf("errdecode: rule %d: %s", e.code, strings.TrimPrefix(e.err.Error(), "errdecode: "))
+}
+
+func (e *ruleError) Unwrap() error { return e.err }
+
+// Compile resolves the registered names referenced by the catalog into
+// rules. Catalogs with problems are rejected, see Validate.
+func (c *Catalog) Compile() ([]Rule, error) {
+	if errs := c.Validate(); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	rules := make([]Rule, 0, len(c.Rules))
+	for _, cr := range c.Rules {
+		rule := Rule{
+			Code:       cr.Code,
+			Message:    cr.Message,
+			Retryable:  cr.Retryable,
+			Priority:   cr.Priority,
+			HTTPStatus: cr.HTTPStatus,
+			GRPCCode:   cr.GRPCCode,
+			Tags:       cr.Tags,
+			DocsURL:    cr.DocsURL,
+			Namespace:  cr.Namespace,
+			Deprecated: cr.Deprecated,
+			ReplacedBy: cr.ReplacedBy,
+		}
+		// Validated above.
+		rule.RetryAfter, _ = time.ParseDuration(cr.RetryAfter)
+		rule.Severity.UnmarshalText([]byte(cr.Severity))
+		for _, name := range cr.Errors {
+			err, lookupErr := lookupError(name)
+			if lookupErr != nil {
+				return nil, &ruleError{cr.Code, lookupErr}
+			}
+			rule.Errors = append(rule.Errors, err)
+		}
+		if cr.Matcher != "" {
+			m, err := lookupMatcher(cr.Matcher)
+			if err != nil {
+				return nil, &ruleError{cr.Code, err}
+			}
+			rule.Match = m
+		}
+		rules = append(rules, rule)
+	}
+	if err := checkRanges(rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,221 @@
+package errdecode_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iamrgon/errdecode"
+)
+
+var errCatalogToken = errors.New("invalid token")
+var errCatalogEmail = errors.New("missing email")
+
+func init() {
+	errdecode.RegisterError("catalog.invalid_token", errCatalogToken)
+	errdecode.RegisterError("catalog.missing_email", errCatalogEmail)
+	errdecode.RegisterMatcher("catalog.custom", func(err error) bool {
+		var ce *CustomError
+		return errors.As(err, &ce)
+	})
+}
+
+const yamlCatalog = `
+rules:
+  - code: 1001
+    message: error.auth
+    errors: [catalog.invalid_token, catalog.missing_email]
+    severity: warning
+  - code: 1002
+    message: error.custom
+    matcher: catalog.custom
+    retryable: true
+    retry_after: 30s
+    priority: 5
+`
+
+func TestLoadYAML(t *testing.T) {
+	rules, err := errdecode.LoadYAML(strings.NewReader(yamlCatalog))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("unexpected number of rules: got=%d want=2", len(rules))
+	}
+	if r := rules[0]; r.Code != 1001 || len(r.Errors) != 2 || r.Severity != errdecode.SeverityWarning {
+		t.Fatalf("unexpected first rule: %+v", r)
+	}
+	if r := rules[1]; r.Match == nil || !r.Retryable || r.RetryAfter != 30*time.Second || r.Priority != 5 {
+		t.Fatalf("unexpected second rule: %+v", r)
+	}
+
+	dec := errdecode.New(rules)
+	if code := errdecode.Code(dec.Translate(errCatalogEmail)); code != 1001 {
+		t.Fatalf("unexpected code for registered error: got=%d want=1001", code)
+	}
+	if code := errdecode.Code(dec.Translate(newCustomError("custom"))); code != 1002 {
+		t.Fatalf("unexpected code for registered matcher: got=%d want=1002", code)
+	}
+}
+
+func TestLoadYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr error
+	}{
+		{"unregistered error", "rules: [{code: 1, errors: [catalog.unknown]}]", errdecode.ErrUnregistered},
+		{"unregistered matcher", "rules: [{code: 1, matcher: catalog.unknown}]", errdecode.ErrUnregistered},
+		{"unknown severity", "rules: [{code: 1, severity: dire}]", nil},
+		{"unknown key", "rules: [{code: 1, mesage: typo}]", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := errdecode.LoadYAML(strings.NewReader(tt.yaml))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: got=%v want=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadYAMLEmpty(t *testing.T) {
+	rules, err := errdecode.LoadYAML(strings.NewReader(""))
+	if err != nil || len(rules) != 0 {
+		t.Fatalf("expected an empty catalog: rules=%v err=%v", rules, err)
+	}
+}
+
+const jsonCatalog = `{
+	"rules": [
+		{"code": 1001, "message": "error.auth", "errors": ["catalog.invalid_token"], "http_status": 401, "tags": ["auth"]},
+		{"code": 1002, "message": "error.custom", "matcher": "catalog.custom", "severity": "critical"}
+	]
+}`
+
+func TestLoadJSON(t *testing.T) {
+	rules, err := errdecode.LoadJSON(strings.NewReader(jsonCatalog))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("unexpected number of rules: got=%d want=2", len(rules))
+	}
+	if r := rules[0]; r.HTTPStatus != 401 || len(r.Tags) != 1 || r.Tags[0] != "auth" || r.Errors[0] != errCatalogToken {
+		t.Fatalf("unexpected first rule: %+v", r)
+	}
+	if r := rules[1]; r.Match == nil || r.Severity != errdecode.SeverityCritical {
+		t.Fatalf("unexpected second rule: %+v", r)
+	}
+}
+
+func TestCatalogValidate(t *testing.T) {
+	c, err := errdecode.ReadCatalog(strings.NewReader(`{"rules": [
+		{"code": 1, "name": "a", "http_status": 42},
+		{"code": 1, "name": "a", "grpc_code": 17, "retry_after": "soon"},
+		{"code": 2, "severity": "fatal"}
+	]}`), ".json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"errdecode: rule 1: invalid HTTP status 42",
+		"errdecode: rule 1: duplicate code",
+		`errdecode: rule 1: name "a" already used by rule 1`,
+		`errdecode: rule 1: invalid retry_after "soon"`,
+		"errdecode: rule 1: invalid gRPC code 17",
+		`errdecode: rule 2: unknown severity "fatal"`,
+	}
+	errs := c.Validate()
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors: got=%q want=%q", errs, want)
+	}
+	for i, err := range errs {
+		if err.Error() != want[i] {
+			t.Fatalf("unexpected error %d: got=%q want=%q", i, err, want[i])
+		}
+	}
+	if !errors.Is(errs[1], errdecode.ErrDuplicateCode) {
+		t.Fatalf("unexpected error: got=%v want=%v", errs[1], errdecode.ErrDuplicateCode)
+	}
+	if _, err := c.Compile(); !errors.Is(err, errdecode.ErrDuplicateCode) {
+		t.Fatalf("unexpected compile error: got=%v want=%v", err, errdecode.ErrDuplicateCode)
+	}
+}
+
+func TestLoadJSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"malformed", `{"rules": [`},
+		{"unknown key", `{"rules": [{"code": 1, "mesage": "typo"}]}`},
+		{"invalid HTTP status", `{"rules": [{"code": 1, "http_status": 42}]}`},
+		{"invalid gRPC code", `{"rules": [{"code": 1, "grpc_code": 17}]}`},
+		{"invalid retry_after", `{"rules": [{"code": 1, "retry_after": "soon"}]}`},
+		{"unregistered error", `{"rules": [{"code": 1, "errors": ["catalog.unknown"]}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := errdecode.LoadJSON(strings.NewReader(tt.json)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+const tomlCatalog = `
+[[rules]]
+code = 1001
+message = "error.auth"
+errors = ["catalog.invalid_token"]
+http_status = 401
+
+[[rules]]
+code = 1002
+message = "error.custom"
+matcher = "catalog.custom"
+retryable = true
+`
+
+func TestLoadTOML(t *testing.T) {
+	rules, err := errdecode.LoadTOML(strings.NewReader(tomlCatalog))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("unexpected number of rules: got=%d want=2", len(rules))
+	}
+	if r := rules[0]; r.HTTPStatus != 401 || r.Errors[0] != errCatalogToken {
+		t.Fatalf("unexpected first rule: %+v", r)
+	}
+	if r := rules[1]; r.Match == nil || !r.Retryable {
+		t.Fatalf("unexpected second rule: %+v", r)
+	}
+}
+
+func TestLoadTOMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		toml string
+	}{
+		{"malformed", "[[rules]\ncode = 1"},
+		{"unknown key", "[[rules]]\ncode = 1\nmesage = \"typo\""},
+		{"unregistered matcher", "[[rules]]\ncode = 1\nmatcher = \"catalog.unknown\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := errdecode.LoadTOML(strings.NewReader(tt.toml)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package errdecode
+
+import "sort"
+
+// CatalogDiff describes the changes between two versions of a catalog, see
+// DiffCatalogs. Rules are listed in ascending order of code.
+type CatalogDiff struct {
+	// Added are the rules whose code is new.
+	Added []CatalogRule
+
+	// Removed are the rules whose code is gone.
+	Removed []CatalogRule
+
+	// Renumbered are the rules moved to another code.
+	Renumbered []CatalogChange
+
+	// Reworded are the rules whose message changed under the same code.
+	Reworded []CatalogChange
+}
+
+// CatalogChange pairs the old and new versions of a rule.
+type CatalogChange struct {
+	Old, New CatalogRule
+}
+
+// DiffCatalogs compares two versions of a catalog.
+//
+// Rules are identified by their name, or their message if unnamed, across
+// all codes. A rule found under another code is reported as renumbered, e.g.,
+// when two codes are swapped. Otherwise, a rule whose identity changed under
+// the same code is reported as reworded, and other rules as removed or added.
+func DiffCatalogs(old, new *Catalog) CatalogDiff {
+	olds, news := sortedCatalogRules(old), sortedCatalogRules(new)
+	oldDone, newDone := make([]bool, len(olds)), make([]bool, len(news))
+
+	// pair records the first unpaired new rule matching each unpaired old
+	// rule.
+	pair := func(match func(o, n CatalogRule) bool, record func(o, n CatalogRule)) {
+		for i, o := range olds {
+			if oldDone[i] {
+				continue
+			}
+			for j, n := range news {
+				if !newDone[j] && match(o, n) {
+					oldDone[i], newDone[j] = true, true
+					record(o, n)
+					break
+				}
+			}
+		}
+	}
+
+	var diff CatalogDiff
+	reword := func(o, n CatalogRule) {
+		if n.Message != o.Message {
+			diff.Reworded = append(diff.Reworded, CatalogChange{o, n})
+		}
+	}
+	pair(func(o, n CatalogRule) bool {
+		return o.Code == n.Code && catalogIdentity(o) == catalogIdentity(n)
+	}, reword)
+	pair(func(o, n CatalogRule) bool {
+		return catalogIdentity(o) == catalogIdentity(n)
+	}, func(o, n CatalogRule) {
+		diff.Renumbered = append(diff.Renumbered, CatalogChange{o, n})
+	})
+	pair(func(o, n CatalogRule) bool { return o.Code == n.Code }, reword)
+
+	for i, o := range olds {
+		if !oldDone[i] {
+			diff.Removed = append(diff.Removed, o)
+		}
+	}
+	for j, n := range news {
+		if !newDone[j] {
+			diff.Added = append(diff.Added, n)
+		}
+	}
+	sort.Slice(diff.Reworded, func(i, j int) bool { return diff.Reworded[i].Old.Code < diff.Reworded[j].Old.Code })
+	return diff
+}
+
+// Breaking reports whether clients relying on the old catalog may break,
+// i.e., whether codes were removed or renumbered.
+func (d CatalogDiff) Breaking() bool {
+	return len(d.Removed) > 0 || len(d.Renumbered) > 0
+}
+
+// Empty reports whether the catalogs have the same codes and messages.
+func (d CatalogDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Renumbered) == 0 && len(d.Reworded) == 0
+}
+
+func sortedCatalogRules(c *Catalog) []CatalogRule {
+	if c == nil {
+		return nil
+	}
+	rs := make([]CatalogRule, len(c.Rules))
+	copy(rs, c.Rules)
+	sort.SliceStable(rs, func(i, j int) bool { return rs[i].Code < rs[j].Code })
+	return rs
+}
+
+func catalogIdentity(r CatalogRule) string {
+	if r.Name != "" {
+		return "name:" + r.Name
+	}
+	return "message:" + r.Message
+}
@@ -0,0 +1,130 @@
+package errdecode_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestDiffCatalogs(t *testing.T) {
+	read := func(src string) *errdecode.Catalog {
+		c, err := errdecode.ReadCatalog(strings.NewReader(src), ".yaml")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return c
+	}
+	codes := func(rs []errdecode.CatalogRule) []int {
+		var codes []int
+		for _, r := range rs {
+			codes = append(codes, r.Code)
+		}
+		return codes
+	}
+	changes := func(cs []errdecode.CatalogChange) [][2]int {
+		var codes [][2]int
+		for _, c := range cs {
+			codes = append(codes, [2]int{c.Old.Code, c.New.Code})
+		}
+		return codes
+	}
+
+	old := read(`
+rules:
+  - {code: 1001, message: error.auth}
+  - {code: 1002, message: error.gone}
+  - {code: 1003, message: error.moved}
+  - {code: 1004, name: Named, message: error.named}
+  - {code: 1005, message: error.same}
+`)
+
+	tests := []struct {
+		name           string
+		new            string
+		wantAdded      []int
+		wantRemoved    []int
+		wantRenumbered [][2]int
+		wantReworded   [][2]int
+		wantBreaking   bool
+	}{
+		{
+			name: "unchanged",
+			new: `
+rules:
+  - {code: 1001, message: error.auth}
+  - {code: 1002, message: error.gone}
+  - {code: 1003, message: error.moved}
+  - {code: 1004, name: Named, message: error.named}
+  - {code: 1005, message: error.same}
+`,
+		},
+		{
+			name: "changes",
+			new: `
+rules:
+  - {code: 1001, message: error.authentication}
+  - {code: 1005, message: error.same}
+  - {code: 2003, message: error.moved}
+  - {code: 2004, name: Named, message: error.renamed}
+  - {code: 2005, message: error.new}
+`,
+			wantAdded:      []int{2005},
+			wantRemoved:    []int{1002},
+			wantRenumbered: [][2]int{{1003, 2003}, {1004, 2004}},
+			wantReworded:   [][2]int{{1001, 1001}},
+			wantBreaking:   true,
+		},
+		{
+			name: "swapped codes",
+			new: `
+rules:
+  - {code: 1001, message: error.gone}
+  - {code: 1002, message: error.auth}
+  - {code: 1003, message: error.moved}
+  - {code: 1004, name: Named, message: error.named}
+  - {code: 1005, message: error.same}
+`,
+			wantRenumbered: [][2]int{{1001, 1002}, {1002, 1001}},
+			wantBreaking:   true,
+		},
+		{
+			name: "additions",
+			new: `
+rules:
+  - {code: 1001, message: error.auth}
+  - {code: 1002, message: error.gone}
+  - {code: 1003, message: error.moved}
+  - {code: 1004, name: Named, message: error.named}
+  - {code: 1005, message: error.same}
+  - {code: 1006, message: error.new}
+`,
+			wantAdded: []int{1006},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := errdecode.DiffCatalogs(old, read(tt.new))
+			if got := codes(diff.Added); !reflect.DeepEqual(got, tt.wantAdded) {
+				t.Fatalf("unexpected added: got=%v want=%v", got, tt.wantAdded)
+			}
+			if got := codes(diff.Removed); !reflect.DeepEqual(got, tt.wantRemoved) {
+				t.Fatalf("unexpected removed: got=%v want=%v", got, tt.wantRemoved)
+			}
+			if got := changes(diff.Renumbered); !reflect.DeepEqual(got, tt.wantRenumbered) {
+				t.Fatalf("unexpected renumbered: got=%v want=%v", got, tt.wantRenumbered)
+			}
+			if got := changes(diff.Reworded); !reflect.DeepEqual(got, tt.wantReworded) {
+				t.Fatalf("unexpected reworded: got=%v want=%v", got, tt.wantReworded)
+			}
+			if diff.Breaking() != tt.wantBreaking {
+				t.Fatalf("unexpected breaking: got=%t want=%t", diff.Breaking(), tt.wantBreaking)
+			}
+			if wantEmpty := tt.name == "unchanged"; diff.Empty() != wantEmpty {
+				t.Fatalf("unexpected empty: got=%t want=%t", diff.Empty(), wantEmpty)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package errdecode
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// ErrDuplicateCode is returned when merged rule catalogs declare the same
+// code more than once.
+var ErrDuplicateCode = errors.New("errdecode: duplicate code")
+
+// LoadFS reads and merges the rule catalogs matching glob in fsys, e.g., one
+// catalog per domain embedded with embed.FS:
+//
+//	//go:embed errors/*.yaml
+//	var catalogs embed.FS
+//
+//	rules, err := errdecode.LoadFS(catalogs, "errors/*.yaml")
+//
+// The format of each file is chosen by its extension: ".yaml" or ".yml",
+// ".json" and ".toml". Files are merged in lexical order, and a code declared
+// by more than one rule is reported as ErrDuplicateCode.
+func LoadFS(fsys fs.FS, glob string) ([]Rule, error) {
+	names, err := fs.Glob(fsys, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []Rule
+	origin := make(map[int]string)
+	for _, name := range names {
+		rs, err := loadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for _, rule := range rs {
+			if prev, dup := origin[rule.Code]; dup {
+				return nil, fmt.Errorf("%s: %w %d, already declared in %s", name, ErrDuplicateCode, rule.Code, prev)
+			}
+			origin[rule.Code] = name
+		}
+		rules = append(rules, rs...)
+	}
+	return rules, nil
+}
+
+func loadFile(fsys fs.FS, name string) ([]Rule, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return loadCatalog(f, path.Ext(name))
+}
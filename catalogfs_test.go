@@ -0,0 +1,63 @@
+package errdecode_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"errors/auth.yaml":    {Data: []byte("rules: [{code: 1001, message: error.auth, errors: [catalog.invalid_token]}]")},
+		"errors/billing.json": {Data: []byte(`{"rules": [{"code": 2001, "message": "error.billing"}]}`)},
+		"errors/infra.toml":   {Data: []byte("[[rules]]\ncode = 3001\nmessage = \"error.infra\"")},
+		"errors/README.md":    {Data: []byte("not a catalog")},
+	}
+
+	rules, err := errdecode.LoadFS(fsys, "errors/*.[jty]*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{1001, 2001, 3001}
+	if len(rules) != len(want) {
+		t.Fatalf("unexpected number of rules: got=%d want=%d", len(rules), len(want))
+	}
+	for i, rule := range rules {
+		if rule.Code != want[i] {
+			t.Fatalf("unexpected code at %d: got=%d want=%d", i, rule.Code, want[i])
+		}
+	}
+}
+
+func TestLoadFSErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr error
+	}{
+		{
+			"duplicate code",
+			fstest.MapFS{
+				"a.yaml": {Data: []byte("rules: [{code: 1001, message: a}]")},
+				"b.json": {Data: []byte(`{"rules": [{"code": 1001, "message": "b"}]}`)},
+			},
+			errdecode.ErrDuplicateCode,
+		},
+		{"unsupported format", fstest.MapFS{"a.ini": {Data: []byte("code = 1")}}, nil},
+		{"invalid catalog", fstest.MapFS{"a.json": {Data: []byte("{")}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := errdecode.LoadFS(tt.fsys, "*")
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: got=%v want=%v", err, tt.wantErr)
+			}
+		})
+	}
+}
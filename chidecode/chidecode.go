@@ -0,0 +1,66 @@
+// Package chidecode renders errors classified by an errdecode.Decoder with
+// go-chi/render:
+//
+//	func getOrder(w http.ResponseWriter, r *http.Request) {
+//		order, err := store.Find(r.Context(), chi.URLParam(r, "id"))
+//		if err != nil {
+//			render.Render(w, r, chidecode.Renderer(decoder, err))
+//			return
+//		}
+//		render.JSON(w, r, order)
+//	}
+//
+// Responses have the status, the Retry-After header and the
+// errdecode.ErrorPayload body written by httpdecode.
+package chidecode
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/httpdecode"
+)
+
+// ErrResponse is a render.Renderer for a classified error.
+type ErrResponse struct {
+	// Err is the error to render.
+	Err error
+
+	// Status is the HTTP status of the response, set by Render.
+	Status int
+
+	d       *errdecode.Decoder
+	m       *httpdecode.Middleware
+	payload errdecode.ErrorPayload
+}
+
+// Renderer returns a renderer translating err with d. The response body is
+// encoded by render, so the httpdecode.ProblemDetails option has no effect.
+func Renderer(d *errdecode.Decoder, err error, options ...httpdecode.Option) *ErrResponse {
+	return &ErrResponse{Err: err, d: d, m: httpdecode.New(d, options...)}
+}
+
+// Render satisfies the render.Renderer interface. It translates the error
+// with the request context and sets the response status, and the Retry-After
+// header of retryable rules, see httpdecode.RetryAfter.
+func (e *ErrResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	var ce errdecode.ClassifiedError
+	ce, e.Status = e.m.Classify(r.Context(), e.Err)
+	e.payload = e.d.Payload(r.Context(), ce)
+	if rule, ok := e.d.RuleFor(ce.Code()); ok {
+		if v := httpdecode.RetryAfter(rule); v != "" {
+			w.Header().Set("Retry-After", v)
+		}
+	}
+	render.Status(r, e.Status)
+	return nil
+}
+
+// MarshalJSON satisfies the json.Marshaler interface, writing the
+// errdecode.ErrorPayload of the error. The error must have been rendered
+// first.
+func (e *ErrResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.payload)
+}
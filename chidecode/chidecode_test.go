@@ -0,0 +1,82 @@
+package chidecode_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/chidecode"
+)
+
+var (
+	errNotFound  = errors.New("order not found")
+	errUnhandled = errors.New("connection reset by peer")
+)
+
+func TestRenderer(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}, HTTPStatus: http.StatusNotFound, Severity: errdecode.SeverityInfo},
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{"classified", errNotFound, http.StatusNotFound, `{"code":1001,"message":"Order not found.","severity":"info","correlation_id":"req-1"}` + "\n"},
+		{"unclassified", errUnhandled, http.StatusInternalServerError, `{"code":0,"message":"Internal Server Error","correlation_id":"req-1"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+			r = r.WithContext(errdecode.WithCorrelationID(r.Context(), "req-1"))
+			if err := render.Render(rec, r, chidecode.Renderer(dec, tt.err)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("unexpected status: got=%d want=%d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Fatalf("unexpected body: got=%s want=%s", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRendererRetryAfter(t *testing.T) {
+	errBusy := errors.New("busy")
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Busy.", Errors: []error{errBusy}, HTTPStatus: http.StatusServiceUnavailable, Retryable: true, RetryAfter: 1500 * time.Millisecond},
+		{Code: 1002, Message: "Order not found.", Errors: []error{errNotFound}, RetryAfter: time.Minute},
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"hint", errBusy, "2"},
+		{"not retryable", errNotFound, ""},
+		{"unclassified", errUnhandled, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+			if err := render.Render(rec, r, chidecode.Renderer(dec, tt.err)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.want {
+				t.Fatalf("unexpected Retry-After: got=%q want=%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/iamrgon/errdecode/chidecode
+
+go 1.21
+
+require (
+	github.com/go-chi/render v1.0.3
+	github.com/iamrgon/errdecode v0.1.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	github.com/ajg/form v1.5.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
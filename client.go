@@ -0,0 +1,125 @@
+package errdecode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// maxErrorBody is the maximum size of an error payload read by
+// ParseResponse.
+const maxErrorBody = 1 << 20
+
+// ResponseError describes an HTTP error response, see ParseResponse.
+type ResponseError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Status is the HTTP status line of the response, e.g., "404 Not Found".
+	Status string
+}
+
+// Error satisfies the error interface.
+func (e *ResponseError) Error() string { return "errdecode: unexpected response: " + e.Status }
+
+// FromHTTPStatus returns a *ResponseError describing an HTTP response with a
+// status of 400 or above, and nil otherwise, so that failures of outbound
+// requests, e.g., to third-party APIs, can be classified like other errors:
+//
+//	if err := errdecode.FromHTTPStatus(resp); err != nil {
+//		return decoder.Translate(err)
+//	}
+//
+// Unlike ParseResponse, the body is not read. Rules match the status of the
+// response with StatusRange.
+func FromHTTPStatus(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	return &ResponseError{StatusCode: resp.StatusCode, Status: resp.Status}
+}
+
+// StatusRange returns a matcher reporting whether an error wraps a
+// *ResponseError with a status code from lo to hi, inclusive.
+func StatusRange(lo, hi int) MatcherFunc {
+	return func(err error) bool {
+		var re *ResponseError
+		return errors.As(err, &re) && lo <= re.StatusCode && re.StatusCode <= hi
+	}
+}
+
+// ParseResponse returns the error described by an HTTP response, so that API
+// clients can check the code of errors instead of matching response bodies:
+//
+//	resp, err := http.Get(url)
+//	if err != nil {
+//		return err
+//	}
+//	defer resp.Body.Close()
+//	if err := errdecode.ParseResponse(resp); err != nil {
+//		if errdecode.Code(err) == 1001 {
+//			// ...
+//		}
+//		return err
+//	}
+//
+// It returns nil for responses with a status below 400. Otherwise, it reads
+// the body and, if it holds an ErrorPayload or problem details with a "code"
+// member, returns a ClassifiedError with its code, message and details. The
+// error is retryable if the response has a Retry-After header, and it wraps
+// a *ResponseError. Other error responses are reported as a *ResponseError.
+//
+// The body is read but not closed.
+func ParseResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	re := &ResponseError{StatusCode: resp.StatusCode, Status: resp.Status}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "application/json" && mediaType != "application/problem+json" {
+		return re
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil {
+		return fmt.Errorf("errdecode: reading error response: %w", err)
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return re
+	}
+	var code int
+	if err := json.Unmarshal(payload["code"], &code); err != nil || code == 0 {
+		return re
+	}
+
+	if mediaType == "application/problem+json" {
+		e := &matchedError{code: code, err: re, retryable: resp.Header.Get("Retry-After") != ""}
+		e.fields = make(map[string]interface{})
+		for k, v := range payload {
+			switch k {
+			case "code", "type", "status", "detail", "instance":
+			case "title":
+				json.Unmarshal(v, &e.msg)
+			default:
+				var field interface{}
+				json.Unmarshal(v, &field)
+				e.fields[k] = field
+			}
+		}
+		e.key = e.msg
+		return e
+	}
+
+	var p ErrorPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return re
+	}
+	e := p.Err().(*matchedError)
+	e.err, e.retryable = re, resp.Header.Get("Retry-After") != ""
+	return e
+}
@@ -0,0 +1,124 @@
+package errdecode_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		header        http.Header
+		body          string
+		wantCode      int
+		wantMessage   string
+		wantField     string
+		wantRetryable bool
+	}{
+		{"success", 200, nil, `{"code": 1001}`, 0, "", "", false},
+		{"json", 404, http.Header{"Content-Type": {"application/json; charset=utf-8"}}, `{"code":1001,"message":"Order not found.","details":{"id":"42"}}`, 1001, "Order not found.", "id", false},
+		{"problem", 503, http.Header{"Content-Type": {"application/problem+json"}, "Retry-After": {"30"}}, `{"type":"about:blank","title":"Try again later.","status":503,"code":1002,"region":"eu"}`, 1002, "Try again later.", "region", true},
+		{"no code", 500, http.Header{"Content-Type": {"application/json"}}, `{"error":"boom"}`, 0, "", "", false},
+		{"malformed", 500, http.Header{"Content-Type": {"application/json"}}, `{"code":`, 0, "", "", false},
+		{"html", 502, http.Header{"Content-Type": {"text/html"}}, `<h1>Bad Gateway</h1>`, 0, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			for k, vs := range tt.header {
+				rec.Header()[k] = vs
+			}
+			rec.WriteHeader(tt.status)
+			rec.WriteString(tt.body)
+
+			err := errdecode.ParseResponse(rec.Result())
+			if tt.status < 400 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var re *errdecode.ResponseError
+			if !errors.As(err, &re) || re.StatusCode != tt.status {
+				t.Fatalf("unexpected response error: got=%v want status %d", err, tt.status)
+			}
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			if tt.wantCode == 0 {
+				return
+			}
+
+			var ce errdecode.ClassifiedError
+			errors.As(err, &ce)
+			if ce.Error() != tt.wantMessage {
+				t.Fatalf("unexpected message: got=%s want=%s", ce.Error(), tt.wantMessage)
+			}
+			if _, ok := ce.Fields()[tt.wantField]; !ok {
+				t.Fatalf("unexpected fields: got=%v want %s", ce.Fields(), tt.wantField)
+			}
+			if ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
+
+var errClientNotFound = errors.New("not found")
+
+func TestParseResponseRoundTrip(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "Not found.", Errors: []error{errClientNotFound}}})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(dec.Translate(errClientNotFound))
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	err = errdecode.ParseResponse(resp)
+	if !errdecode.IsCode(err, 1001) || !strings.Contains(err.Error(), "Not found.") {
+		t.Fatalf("unexpected error: got=%v want code 1001", err)
+	}
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantMatch bool
+	}{
+		{"ok", http.StatusOK, false},
+		{"redirect", http.StatusFound, false},
+		{"lower bound", http.StatusTooManyRequests, true},
+		{"upper bound", http.StatusServiceUnavailable, true},
+		{"out of range", http.StatusBadRequest, false},
+	}
+
+	match := errdecode.StatusRange(http.StatusTooManyRequests, http.StatusServiceUnavailable)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errdecode.FromHTTPStatus(&http.Response{StatusCode: tt.status, Status: http.StatusText(tt.status)})
+			if (err != nil) != (tt.status >= 400) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := err != nil && match(err); got != tt.wantMatch {
+				t.Fatalf("unexpected match: got=%t want=%t", got, tt.wantMatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Command errdecode-gen generates Go code from an errdecode rule catalog:
+// typed code constants, a rules loader and exhaustive switch helpers.
+//
+// It is meant to be used with go generate:
+//
+//	//go:generate errdecode-gen -in errors.yaml -pkg errcodes -out errcodes_gen.go
+//
+// With -lang proto, it emits a protobuf enum of the codes instead, so that
+// gRPC clients in other languages can reference codes symbolically:
+//
+//	errdecode-gen -lang proto -in errors.yaml -pkg acme.errors.v1 -enum ErrorCode -out errors.proto
+//
+// Constants are named after the optional name of each rule, e.g., a rule
+// named "InvalidToken" yields CodeInvalidToken. Unnamed rules are named after
+// their message key, or their code if the message is not a key.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("errdecode-gen: ")
+
+	in := flag.String("in", "", "catalog file (.yaml, .yml, .json or .toml)")
+	out := flag.String("out", "", "output file (default stdout)")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated file")
+	lang := flag.String("lang", "go", "output language (go or proto)")
+	enum := flag.String("enum", "ErrorCode", "name of the generated protobuf enum")
+	flag.Parse()
+
+	if *in == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var code []byte
+	switch *lang {
+	case "go":
+		code, err = generate(src, filepath.Base(*in), *pkg)
+	case "proto":
+		code, err = generateProto(src, filepath.Base(*in), *pkg, *enum)
+	default:
+		err = fmt.Errorf("unsupported language %q", *lang)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(code)
+		return
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted Go source for the catalog src, read from
+// the file name.
+func generate(src []byte, name, pkg string) ([]byte, error) {
+	ext := filepath.Ext(name)
+	c, err := errdecode.ReadCatalog(bytes.NewReader(src), ext)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := constants(c)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Source  string
+		Package string
+		Loader  string
+		Catalog string
+		Codes   []constant
+	}{
+		Source:  name,
+		Package: pkg,
+		Loader:  loaders[ext],
+		Catalog: strconv.Quote(string(src)),
+		Codes:   codes,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+type constant struct {
+	Name    string
+	Code    int
+	Message string
+}
+
+// constants returns the constant for every rule of the catalog. Empty
+// catalogs and duplicate codes are rejected, as they generate invalid
+// switch statements.
+func constants(c *errdecode.Catalog) ([]constant, error) {
+	if len(c.Rules) == 0 {
+		return nil, fmt.Errorf("catalog declares no rules")
+	}
+	var codes []constant
+	seen := make(map[string]int)
+	names := make(map[int]string)
+	for _, r := range c.Rules {
+		ident := "Code" + identifier(r)
+		if name, dup := names[r.Code]; dup {
+			return nil, fmt.Errorf("code %d is declared by rules %s and %s", r.Code, name, ident)
+		}
+		names[r.Code] = ident
+		if code, dup := seen[ident]; dup {
+			return nil, fmt.Errorf("rules %d and %d both generate %s", code, r.Code, ident)
+		}
+		seen[ident] = r.Code
+		codes = append(codes, constant{ident, r.Code, r.Message})
+	}
+	return codes, nil
+}
+
+var loaders = map[string]string{
+	".yaml": "LoadYAML",
+	".yml":  "LoadYAML",
+	".json": "LoadJSON",
+	".toml": "LoadTOML",
+}
+
+// identifier returns the exported identifier for a rule. Negative codes are
+// spelled with a Neg prefix, e.g., Neg5 for -5.
+func identifier(r errdecode.CatalogRule) string {
+	if r.Name != "" {
+		return exported(r.Name)
+	}
+	if r.Message != "" && !strings.ContainsAny(r.Message, " \t\n") {
+		return exported(r.Message)
+	}
+	if r.Code < 0 {
+		return "Neg" + strconv.Itoa(-r.Code)
+	}
+	return strconv.Itoa(r.Code)
+}
+
+// exported converts s to an exported identifier, e.g., "error.auth_token"
+// becomes "ErrorAuthToken".
+func exported(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by errdecode-gen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"strings"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// Codes declared by the catalog.
+const (
+{{- range .Codes}}
+	{{.Name}} = {{.Code}} // {{printf "%q" .Message}}
+{{- end}}
+)
+
+const catalog = {{.Catalog}}
+
+// Rules returns the rules declared by the catalog. The error values and
+// matchers it references must have been registered beforehand.
+func Rules() ([]errdecode.Rule, error) {
+	return errdecode.{{.Loader}}(strings.NewReader(catalog))
+}
+
+// IsKnown reports whether code is declared by the catalog.
+func IsKnown(code int) bool {
+	switch code {
+	case {{range $i, $c := .Codes}}{{if $i}}, {{end}}{{$c.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+
+// CodeName returns the name of the constant declaring code, or an empty
+// string if code is not declared by the catalog.
+func CodeName(code int) string {
+	switch code {
+{{- range .Codes}}
+	case {{.Name}}:
+		return "{{.Name}}"
+{{- end}}
+	}
+	return ""
+}
+`))
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := os.ReadFile("testdata/errors.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code, err := generate(src, "errors.yaml", "errcodes")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "errcodes_gen.go", code, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, code)
+	}
+
+	normalized := strings.Join(strings.Fields(string(code)), " ")
+	for _, want := range []string{
+		"CodeInvalidToken = 1001",
+		"CodeErrorMissingCredentials = 1002",
+		"Code1003 = 1003",
+		"errdecode.LoadYAML(strings.NewReader(catalog))",
+		"case CodeInvalidToken, CodeErrorMissingCredentials, Code1003:",
+		`return "CodeErrorMissingCredentials"`,
+	} {
+		if !strings.Contains(normalized, want) {
+			t.Fatalf("generated code is missing %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestGenerateDuplicateIdentifier(t *testing.T) {
+	src := []byte(`{"rules": [{"name": "auth", "code": 1}, {"name": "Auth", "code": 2}]}`)
+	if _, err := generate(src, "errors.json", "errcodes"); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestGenerateNegativeCode(t *testing.T) {
+	src := []byte(`{"rules": [{"code": -5, "message": "Negative code."}]}`)
+	code, err := generate(src, "errors.json", "errcodes")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "errcodes_gen.go", code, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, code)
+	}
+	if normalized := strings.Join(strings.Fields(string(code)), " "); !strings.Contains(normalized, "CodeNeg5 = -5") {
+		t.Fatalf("generated code is missing %q:\n%s", "CodeNeg5 = -5", code)
+	}
+}
+
+func TestGenerateEmptyCatalog(t *testing.T) {
+	src := []byte(`{"rules": []}`)
+	_, err := generate(src, "errors.json", "errcodes")
+	if err == nil || err.Error() != "catalog declares no rules" {
+		t.Fatalf("unexpected error: got=%v want=%v", err, "catalog declares no rules")
+	}
+}
+
+func TestGenerateDuplicateCode(t *testing.T) {
+	src := []byte(`{"rules": [{"name": "auth", "code": 1}, {"name": "gone", "code": 1}]}`)
+	_, err := generate(src, "errors.json", "errcodes")
+	want := "code 1 is declared by rules CodeAuth and CodeGone"
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got=%v want=%v", err, want)
+	}
+}
+
+func TestGenerateProto(t *testing.T) {
+	src, err := os.ReadFile("testdata/errors.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	proto, err := generateProto(src, "errors.yaml", "acme.errors.v1", "ErrorCode")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		`syntax = "proto3";`,
+		"package acme.errors.v1;",
+		"enum ErrorCode {",
+		"ERROR_CODE_UNSPECIFIED = 0;",
+		"// The provided token is not valid.\n  ERROR_CODE_INVALID_TOKEN = 1001;",
+		"ERROR_CODE_ERROR_MISSING_CREDENTIALS = 1002;",
+		"ERROR_CODE_1003 = 1003;",
+	} {
+		if !strings.Contains(string(proto), want) {
+			t.Fatalf("generated proto is missing %q:\n%s", want, proto)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// generateProto returns a proto3 file declaring an enum of the codes of the
+// catalog src, read from the file name.
+//
+// Values are named after the enum in upper snake case, e.g., ErrorCode and
+// CodeInvalidToken yield ERROR_CODE_INVALID_TOKEN. As required by proto3,
+// the zero value is reserved for ERROR_CODE_UNSPECIFIED.
+func generateProto(src []byte, name, pkg, enum string) ([]byte, error) {
+	c, err := errdecode.ReadCatalog(bytes.NewReader(src), filepath.Ext(name))
+	if err != nil {
+		return nil, err
+	}
+	codes, err := constants(c)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := screamingSnake(enum) + "_"
+	for i := range codes {
+		codes[i].Name = prefix + screamingSnake(strings.TrimPrefix(codes[i].Name, "Code"))
+	}
+
+	data := struct {
+		Source  string
+		Package string
+		Enum    string
+		Prefix  string
+		Codes   []constant
+	}{name, pkg, enum, prefix, codes}
+
+	var buf bytes.Buffer
+	if err := protoTmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// screamingSnake converts an exported identifier to upper snake case, e.g.,
+// "InvalidToken" becomes "INVALID_TOKEN".
+func screamingSnake(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+var protoTmpl = template.Must(template.New("").Parse(`// Code generated by errdecode-gen from {{.Source}}. DO NOT EDIT.
+
+syntax = "proto3";
+{{if .Package}}
+package {{.Package}};
+{{end}}
+// {{.Enum}} enumerates the error codes declared by the catalog.
+enum {{.Enum}} {
+  {{.Prefix}}UNSPECIFIED = 0;
+{{- range .Codes}}
+  // {{.Message}}
+  {{.Name}} = {{.Code}};
+{{- end}}
+}
+`))
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// runCoverage reports the missing and orphaned translations of locale
+// catalogs.
+func runCoverage(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	failOrphaned := fs.Bool("fail-orphaned", false, "fail if translations are not used by any rule")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return fmt.Errorf("coverage: expected a catalog and locale catalogs")
+	}
+
+	c, err := readCatalog(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	locales := make(map[string]errdecode.Messages)
+	for _, name := range fs.Args()[1:] {
+		msgs, err := readLocale(name)
+		if err != nil {
+			return err
+		}
+		locales[name] = msgs
+	}
+
+	failed := false
+	for _, cov := range errdecode.CheckCoverage(c, locales) {
+		for _, key := range cov.Missing {
+			fmt.Fprintf(stdout, "%s: missing translation for %q\n", cov.Locale, key)
+			failed = true
+		}
+		for _, key := range cov.Orphaned {
+			fmt.Fprintf(stdout, "%s: orphaned translation for %q\n", cov.Locale, key)
+			failed = failed || *failOrphaned
+		}
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
+
+// readLocale reads the locale catalog file name, in the format given by its
+// extension.
+func readLocale(name string) (errdecode.Messages, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	msgs, err := errdecode.ReadLocale(f, filepath.Ext(name))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return msgs, nil
+}
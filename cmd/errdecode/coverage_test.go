@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "orphaned",
+			args: []string{"testdata/v1.yaml", "testdata/locales/fr.json"},
+			want: `testdata/locales/fr.json: orphaned translation for "error.removed"
+`,
+		},
+		{
+			name: "fail orphaned",
+			args: []string{"-fail-orphaned", "testdata/v1.yaml", "testdata/locales/fr.json"},
+			want: `testdata/locales/fr.json: orphaned translation for "error.removed"
+`,
+			wantErr: errFailed,
+		},
+		{
+			name: "missing",
+			args: []string{"testdata/v1.yaml", "testdata/locales/de.po"},
+			want: `testdata/locales/de.po: missing translation for "error.gone"
+testdata/locales/de.po: missing translation for "error.moved"
+testdata/locales/de.po: orphaned translation for "error.removed"
+`,
+			wantErr: errFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			err := runCoverage(tt.args, &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: got=%v want=%v", err, tt.wantErr)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("unexpected output: got=%q want=%q", out.String(), tt.want)
+			}
+		})
+	}
+}
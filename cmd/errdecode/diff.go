@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func runDiff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	failBreaking := fs.Bool("fail-breaking", false, "fail if codes were removed or renumbered")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("diff: expected 2 catalogs, got %d", fs.NArg())
+	}
+
+	old, err := readCatalog(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	new, err := readCatalog(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diff := errdecode.DiffCatalogs(old, new)
+	writeDiff(stdout, diff)
+	if *failBreaking && diff.Breaking() {
+		return errFailed
+	}
+	return nil
+}
+
+// writeDiff prints one line per change, prefixed by its kind.
+func writeDiff(w io.Writer, diff errdecode.CatalogDiff) {
+	for _, r := range diff.Removed {
+		fmt.Fprintf(w, "removed     %d %q\n", r.Code, r.Message)
+	}
+	for _, c := range diff.Renumbered {
+		fmt.Fprintf(w, "renumbered  %d -> %d %q\n", c.Old.Code, c.New.Code, c.New.Message)
+	}
+	for _, c := range diff.Reworded {
+		fmt.Fprintf(w, "reworded    %d %q -> %q\n", c.Old.Code, c.Old.Message, c.New.Message)
+	}
+	for _, r := range diff.Added {
+		fmt.Fprintf(w, "added       %d %q\n", r.Code, r.Message)
+	}
+}
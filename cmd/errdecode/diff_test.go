@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "report",
+			args: []string{"testdata/v1.yaml", "testdata/v2.yaml"},
+			want: `removed     1002 "error.gone"
+renumbered  1003 -> 2003 "error.moved"
+reworded    1001 "error.auth" -> "error.authentication"
+added       2004 "error.new"
+`,
+		},
+		{
+			name:    "fail breaking",
+			args:    []string{"-fail-breaking", "testdata/v1.yaml", "testdata/v2.yaml"},
+			want:    "removed",
+			wantErr: errFailed,
+		},
+		{
+			name: "unchanged",
+			args: []string{"-fail-breaking", "testdata/v1.yaml", "testdata/v1.yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			err := runDiff(tt.args, &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: got=%v want=%v", err, tt.wantErr)
+			}
+			if !strings.HasPrefix(out.String(), tt.want) {
+				t.Fatalf("unexpected output: got=%q want=%q", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+func runGotext(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("gotext", flag.ContinueOnError)
+	lang := fs.String("lang", "en-US", "language tag of the message file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("gotext: expected 1 catalog, got %d", fs.NArg())
+	}
+
+	c, err := readCatalog(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return c.ExportGotext(stdout, *lang)
+}
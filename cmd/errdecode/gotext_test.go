@@ -0,0 +1,18 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGotext(t *testing.T) {
+	var out strings.Builder
+	if err := runGotext([]string{"-lang", "de-DE", "testdata/v1.yaml"}, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`"language": "de-DE"`, `"id": "error.auth"`, `"translatorComment": "Error code 1003"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("unexpected output: got=%s want=%s", out.String(), want)
+		}
+	}
+}
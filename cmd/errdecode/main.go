@@ -0,0 +1,100 @@
+// Command errdecode provides tooling for errdecode rule catalogs.
+//
+// Usage:
+//
+//	errdecode coverage [-fail-orphaned] catalog.yaml locale.json ...
+//	errdecode diff [-fail-breaking] old.yaml new.yaml
+//	errdecode gotext [-lang tag] catalog.yaml
+//	errdecode translate -catalog catalog.yaml [-locale tag] [-messages locale.json ...] code ...
+//	errdecode vet catalog.yaml [messages.gotext.json ...]
+//
+// The coverage command reports the messages of a catalog missing from locale
+// catalogs, and the translations no rule uses, see errdecode.ReadLocale for
+// the formats of locale catalogs. It exits with status 1 if translations are
+// missing or, with -fail-orphaned, orphaned, e.g., in CI:
+//
+//	errdecode coverage errors.yaml locales/*.json
+//
+// The diff command reports added, removed, renumbered and re-worded codes
+// between two versions of a catalog. With -fail-breaking, it exits with
+// status 1 if codes were removed or renumbered, e.g., in a release gate:
+//
+//	git show v1.4.0:errors.yaml > /tmp/errors.yaml
+//	errdecode diff -fail-breaking /tmp/errors.yaml errors.yaml
+//
+// The gotext command writes the messages of a catalog as a message file of
+// golang.org/x/text/cmd/gotext, for the language given by -lang:
+//
+//	errdecode gotext -lang fr-FR errors.yaml > locales/fr-FR/messages.gotext.json
+//
+// The translate command prints the messages of codes as users of the given
+// locale see them, e.g., to read a code quoted in a support ticket. Locale
+// catalogs are named after their language tag, and messages missing from them
+// are printed untranslated. It exits with status 1 if a code is unknown:
+//
+//	errdecode translate -catalog errors.yaml -locale fr-CA -messages locales/fr.json 1001
+//
+// The vet command validates a catalog: unknown keys, duplicate codes and
+// names, empty messages, illegal HTTP statuses, gRPC codes, retry delays and
+// severities. Translations
+// of the gotext message files given after the catalog must use the same
+// placeholders as the messages they translate. It exits with status 1 if any
+// problem is found, e.g., in CI:
+//
+//	errdecode vet errors.yaml locales/*/messages.gotext.json
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// errFailed reports a failed check, after its findings have been printed.
+var errFailed = errors.New("check failed")
+
+var commands = map[string]func(args []string, stdout io.Writer) error{
+	"coverage":  runCoverage,
+	"diff":      runDiff,
+	"gotext":    runGotext,
+	"translate": runTranslate,
+	"vet":       runVet,
+}
+
+func main() {
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		fmt.Fprintln(os.Stderr, "usage: errdecode coverage [-fail-orphaned] catalog locale ...")
+		fmt.Fprintln(os.Stderr, "       errdecode diff [-fail-breaking] old new")
+		fmt.Fprintln(os.Stderr, "       errdecode gotext [-lang tag] catalog")
+		fmt.Fprintln(os.Stderr, "       errdecode translate -catalog catalog [-locale tag] [-messages locale ...] code ...")
+		fmt.Fprintln(os.Stderr, "       errdecode vet catalog [messages.gotext.json ...]")
+		os.Exit(2)
+	}
+	err := commands[os.Args[1]](os.Args[2:], os.Stdout)
+	switch {
+	case errors.Is(err, errFailed):
+		os.Exit(1)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "errdecode:", err)
+		os.Exit(1)
+	}
+}
+
+// readCatalog reads the catalog file name, in the format given by its
+// extension.
+func readCatalog(name string) (*errdecode.Catalog, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	c, err := errdecode.ReadCatalog(f, filepath.Ext(name))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// localeFiles is a repeated flag listing locale catalogs.
+type localeFiles []string
+
+func (f *localeFiles) String() string { return strings.Join(*f, ",") }
+
+func (f *localeFiles) Set(name string) error {
+	*f = append(*f, name)
+	return nil
+}
+
+func runTranslate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("translate", flag.ContinueOnError)
+	catalog := fs.String("catalog", "", "rule catalog")
+	locale := fs.String("locale", "", "language tag of the messages")
+	var messages localeFiles
+	fs.Var(&messages, "messages", "locale catalog, named after its language tag, e.g., fr-FR.json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *catalog == "" {
+		return fmt.Errorf("translate: missing -catalog")
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("translate: expected codes")
+	}
+
+	c, err := readCatalog(*catalog)
+	if err != nil {
+		return err
+	}
+	// Only messages are rendered, so matchers and errors, registered by
+	// applications, are not resolved.
+	rules := make([]errdecode.Rule, len(c.Rules))
+	for i, r := range c.Rules {
+		rules[i] = errdecode.Rule{Code: r.Code, Message: r.Message}
+	}
+	opts := []errdecode.Option{errdecode.Lazy()}
+	for _, name := range messages {
+		msgs, err := readLocale(name)
+		if err != nil {
+			return err
+		}
+		lang := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+		opts = append(opts, errdecode.LocaleMessages(lang, msgs))
+	}
+	d := errdecode.New(rules, opts...)
+
+	failed := false
+	for _, arg := range fs.Args() {
+		code, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("translate: invalid code %q", arg)
+		}
+		e, ok := d.ErrorForCode(code)
+		if !ok {
+			fmt.Fprintf(stdout, "%d: unknown code\n", code)
+			failed = true
+			continue
+		}
+		fmt.Fprintf(stdout, "%d: %s\n", code, errdecode.Render(e, *locale))
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
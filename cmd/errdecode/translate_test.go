@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "no messages",
+			args: []string{"-catalog", "testdata/v1.yaml", "1001"},
+			want: "1001: error.auth\n",
+		},
+		{
+			name: "locale",
+			args: []string{"-catalog", "testdata/v1.yaml", "-locale", "fr", "-messages", "testdata/locales/fr.json", "1001", "1002"},
+			want: "1001: Non authentifié.\n1002: Supprimé.\n",
+		},
+		{
+			name: "base language",
+			args: []string{"-catalog", "testdata/v1.yaml", "-locale", "fr-CA", "-messages", "testdata/locales/fr.json", "1003"},
+			want: "1003: Déplacé.\n",
+		},
+		{
+			name: "missing translation",
+			args: []string{"-catalog", "testdata/v1.yaml", "-locale", "de", "-messages", "testdata/locales/fr.json", "-messages", "testdata/locales/de.po", "1001", "1002"},
+			want: "1001: Nicht authentifiziert.\n1002: error.gone\n",
+		},
+		{
+			name:    "unknown code",
+			args:    []string{"-catalog", "testdata/v1.yaml", "1001", "9999"},
+			want:    "1001: error.auth\n9999: unknown code\n",
+			wantErr: errFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			err := runTranslate(tt.args, &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: got=%v want=%v", err, tt.wantErr)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("unexpected output: got=%q want=%q", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iamrgon/errdecode"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// runVet validates a catalog and, optionally, the gotext message files
+// translating it.
+func runVet(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("vet", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 {
+		return fmt.Errorf("vet: expected a catalog")
+	}
+
+	name := fs.Arg(0)
+	c, err := readCatalog(name)
+	if err != nil {
+		fmt.Fprintln(stdout, err)
+		return errFailed
+	}
+	failed := false
+	report := func(file string, problems []string) {
+		for _, p := range problems {
+			fmt.Fprintf(stdout, "%s: %s\n", file, p)
+			failed = true
+		}
+	}
+	report(name, vetCatalog(c))
+	for _, locale := range fs.Args()[1:] {
+		problems, err := vetLocale(c, locale)
+		if err != nil {
+			return err
+		}
+		report(locale, problems)
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
+
+// vetCatalog returns the problems of a catalog, see Catalog.Validate, and
+// the empty messages and misplaced replacements it declares.
+func vetCatalog(c *errdecode.Catalog) []string {
+	var problems []string
+	for _, err := range c.Validate() {
+		problems = append(problems, strings.TrimPrefix(err.Error(), "errdecode: "))
+	}
+	for _, r := range c.Rules {
+		if strings.TrimSpace(r.Message) == "" {
+			problems = append(problems, fmt.Sprintf("rule %d: empty message", r.Code))
+		}
+		if r.ReplacedBy != 0 && !r.Deprecated {
+			problems = append(problems, fmt.Sprintf("rule %d: replaced_by set on a rule that is not deprecated", r.Code))
+		}
+	}
+	return problems
+}
+
+// vetLocale returns the translations of a gotext message file whose
+// placeholders differ from the catalog message they translate.
+func vetLocale(c *errdecode.Catalog, name string) ([]string, error) {
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Messages []struct {
+			ID          string `json:"id"`
+			Translation string `json:"translation"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(src, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	codes := make(map[string]int)
+	for _, r := range c.Rules {
+		if _, ok := codes[r.Message]; !ok {
+			codes[r.Message] = r.Code
+		}
+	}
+	var problems []string
+	for _, m := range file.Messages {
+		code, ok := codes[m.ID]
+		if !ok || m.Translation == "" {
+			continue
+		}
+		want, got := placeholders(m.ID), placeholders(m.Translation)
+		if want != got {
+			problems = append(problems, fmt.Sprintf("rule %d: translation placeholders [%s] do not match message placeholders [%s]", code, got, want))
+		}
+	}
+	return problems, nil
+}
+
+// placeholders returns the sorted, distinct placeholder names of msg.
+func placeholders(msg string) string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, m := range placeholderPattern.FindAllStringSubmatch(msg, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
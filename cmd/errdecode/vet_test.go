@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVet(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "valid",
+			args: []string{"testdata/v1.yaml"},
+		},
+		{
+			name: "invalid",
+			args: []string{"testdata/invalid.yaml", "testdata/locales/fr-FR/messages.gotext.json"},
+			want: []string{
+				"testdata/invalid.yaml: rule 1001: duplicate code",
+				`testdata/invalid.yaml: rule 1001: name "Auth" already used by rule 1001`,
+				"testdata/invalid.yaml: rule 1002: invalid HTTP status 600",
+				`testdata/invalid.yaml: rule 1002: unknown severity "fatal"`,
+				`testdata/invalid.yaml: rule 1003: invalid retry_after "soon"`,
+				"testdata/invalid.yaml: rule 1003: invalid gRPC code 17",
+				"testdata/invalid.yaml: rule 1002: empty message",
+				"testdata/invalid.yaml: rule 1003: replaced_by set on a rule that is not deprecated",
+				"testdata/locales/fr-FR/messages.gotext.json: rule 1001: translation placeholders [scop user] do not match message placeholders [scope user]",
+			},
+			wantErr: errFailed,
+		},
+		{
+			name:    "unknown key",
+			args:    []string{"testdata/unknown.json"},
+			want:    []string{"testdata/unknown.json: errdecode: decode JSON catalog"},
+			wantErr: errFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			err := runVet(tt.args, &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: got=%v want=%v", err, tt.wantErr)
+			}
+			var lines []string
+			if s := strings.TrimSpace(out.String()); s != "" {
+				lines = strings.Split(s, "\n")
+			}
+			if len(lines) != len(tt.want) {
+				t.Fatalf("unexpected output: got=%q want=%q", lines, tt.want)
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(lines[i], want) {
+					t.Fatalf("unexpected line: got=%q want=%q", lines[i], want)
+				}
+			}
+		})
+	}
+}
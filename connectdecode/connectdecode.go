@@ -0,0 +1,100 @@
+// Package connectdecode translates errors returned by Connect handlers into
+// Connect errors with an errdecode.Decoder, mirroring grpcdecode:
+//
+//	path, handler := ordersv1connect.NewOrderServiceHandler(svc,
+//		connect.WithInterceptors(connectdecode.NewInterceptor(decoder)),
+//	)
+//
+// The Connect code is the one of the rule, see errdecode.Rule.RPCCode. The
+// message is the translated message, and the code of the rule is sent in the
+// CodeMeta metadata. Errors also carry standard error details, see Error.
+package connectdecode
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/internal/rpcdetails"
+)
+
+// CodeMeta is the metadata key holding the code of a classified error.
+const CodeMeta = "Errdecode-Code"
+
+// NewInterceptor returns an interceptor translating the errors returned by
+// unary and streaming handlers with d. Clients are left untouched.
+func NewInterceptor(d *errdecode.Decoder, options ...Option) connect.Interceptor {
+	return &interceptor{d, newConfig(options)}
+}
+
+type interceptor struct {
+	d *errdecode.Decoder
+	c *rpcdetails.Config
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		resp, err := next(ctx, req)
+		return resp, i.error(ctx, req.Spec(), err)
+	}
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return i.error(ctx, conn.Spec(), next(ctx, conn))
+	}
+}
+
+func (i *interceptor) error(ctx context.Context, spec connect.Spec, err error) error {
+	if err == nil || spec.IsClient {
+		return err
+	}
+	return translate(ctx, i.d, err, i.c)
+}
+
+// Error translates err with d and returns it as a Connect error.
+//
+// The Connect error of a classified error carries the following details:
+//
+//   - errdetails.ErrorInfo, with the code as reason, the domain set by Domain
+//     and the fields of the error as metadata, see errdecode.Fielder
+//   - errdetails.LocalizedMessage, with the translated message and the locale
+//     set by Locale
+//   - errdetails.RetryInfo, with the RetryAfter delay of retryable rules
+//   - errdetails.BadRequest, with a field violation for field errors, see
+//     errdecode.ClassifiedFieldError
+//
+// Unclassified Connect errors, e.g., returned by a downstream client, are
+// returned as-is. Other unclassified errors are reported as
+// connect.CodeInternal with a generic message, so their message never
+// reaches clients.
+func Error(ctx context.Context, d *errdecode.Decoder, err error, options ...Option) *connect.Error {
+	return translate(ctx, d, err, newConfig(options))
+}
+
+func translate(ctx context.Context, d *errdecode.Decoder, err error, c *rpcdetails.Config) *connect.Error {
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) && !errors.As(d.TranslateContext(ctx, err), &ce) {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			return connectErr
+		}
+		return connect.NewError(connect.CodeInternal, errors.New("internal error"))
+	}
+
+	rule, _ := d.RuleFor(ce.Code())
+	connectErr := connect.NewError(connect.Code(rule.RPCCode()), errors.New(ce.Error()))
+	connectErr.Meta().Set(CodeMeta, strconv.Itoa(ce.Code()))
+	for _, msg := range rpcdetails.Details(ctx, d, ce, rule, c) {
+		if detail, err := connect.NewErrorDetail(msg); err == nil {
+			connectErr.AddDetail(detail)
+		}
+	}
+	return connectErr
+}
@@ -0,0 +1,115 @@
+package connectdecode_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/connectdecode"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	errNotFound  = errors.New("order not found")
+	errUnhandled = errors.New("connection reset by peer")
+)
+
+func TestInterceptor(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}, HTTPStatus: http.StatusNotFound},
+	})
+
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    connect.Code
+		wantMessage string
+		wantMeta    string
+		wantReason  string
+	}{
+		{"classified", errNotFound, connect.CodeNotFound, "Order not found.", "1001", "1001"},
+		{"unclassified", errUnhandled, connect.CodeInternal, "internal error", "", ""},
+		{"connect error", connect.NewError(connect.CodeUnavailable, errors.New("downstream")), connect.CodeUnavailable, "downstream", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.Handle("/orders.v1.OrderService/GetOrder", connect.NewUnaryHandler(
+				"/orders.v1.OrderService/GetOrder",
+				func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+					return nil, tt.err
+				},
+				connect.WithInterceptors(connectdecode.NewInterceptor(dec, connectdecode.Domain("orders.example.com"))),
+			))
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			client := connect.NewClient[emptypb.Empty, emptypb.Empty](srv.Client(), srv.URL+"/orders.v1.OrderService/GetOrder")
+			_, err := client.CallUnary(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+
+			var connectErr *connect.Error
+			if !errors.As(err, &connectErr) {
+				t.Fatalf("unexpected error: got=%v want=*connect.Error", err)
+			}
+			if connectErr.Code() != tt.wantCode || connectErr.Message() != tt.wantMessage {
+				t.Fatalf("unexpected error: got=%s %q want=%s %q", connectErr.Code(), connectErr.Message(), tt.wantCode, tt.wantMessage)
+			}
+			if got := connectErr.Meta().Get(connectdecode.CodeMeta); got != tt.wantMeta {
+				t.Fatalf("unexpected code metadata: got=%q want=%q", got, tt.wantMeta)
+			}
+			var reason string
+			for _, d := range connectErr.Details() {
+				if v, err := d.Value(); err == nil {
+					if info, ok := v.(*errdetails.ErrorInfo); ok {
+						reason = info.Reason
+					}
+				}
+			}
+			if reason != tt.wantReason {
+				t.Fatalf("unexpected reason: got=%q want=%q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestErrorLocale(t *testing.T) {
+	rules := []errdecode.Rule{{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}}}
+
+	tests := []struct {
+		name       string
+		dec        *errdecode.Decoder
+		ctx        context.Context
+		options    []connectdecode.Option
+		wantLocale string
+	}{
+		{"context locale", errdecode.New(rules, errdecode.Locales("fr", "de")), errdecode.WithLocale(context.Background(), "de"), nil, "de"},
+		{"default locale", errdecode.New(rules, errdecode.Locales("fr", "de")), context.Background(), nil, "fr"},
+		{"no locales", errdecode.New(rules), context.Background(), nil, "en-US"},
+		{"option", errdecode.New(rules), context.Background(), []connectdecode.Option{connectdecode.Locale(func(context.Context) string { return "es" })}, "es"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connectErr := connectdecode.Error(tt.ctx, tt.dec, errNotFound, tt.options...)
+			for _, detail := range connectErr.Details() {
+				msg, err := detail.Value()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if localized, ok := msg.(*errdetails.LocalizedMessage); ok {
+					if localized.Locale != tt.wantLocale {
+						t.Fatalf("unexpected locale: got=%s want=%s", localized.Locale, tt.wantLocale)
+					}
+					return
+				}
+			}
+			t.Fatalf("missing localized message")
+		})
+	}
+}
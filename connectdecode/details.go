@@ -0,0 +1,35 @@
+package connectdecode
+
+import (
+	"context"
+
+	"github.com/iamrgon/errdecode/internal/rpcdetails"
+)
+
+// Option configures the Connect errors built from classified errors.
+type Option func(*rpcdetails.Config)
+
+func newConfig(options []Option) *rpcdetails.Config {
+	c := &rpcdetails.Config{}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Domain is used to set the domain of errdetails.ErrorInfo details, e.g.,
+// "orders.acme.com".
+func Domain(domain string) Option {
+	return func(c *rpcdetails.Config) {
+		c.Domain = domain
+	}
+}
+
+// Locale is used to set the locale of errdetails.LocalizedMessage details
+// from the request context. By default, it is the locale the message was
+// translated to, see errdecode.WithLocale and errdecode.Locales.
+func Locale(fn func(ctx context.Context) string) Option {
+	return func(c *rpcdetails.Config) {
+		c.Locale = fn
+	}
+}
@@ -0,0 +1,86 @@
+package errdecode
+
+import "sort"
+
+// LocaleCoverage describes the translations of a locale, see CheckCoverage.
+type LocaleCoverage struct {
+	// Locale is the locale of the catalog.
+	Locale string
+
+	// Missing are the message keys of rules without translation, sorted.
+	Missing []string
+
+	// Orphaned are the translated keys no rule uses, sorted, e.g., the
+	// messages of removed rules.
+	Orphaned []string
+}
+
+// Complete reports whether every message key is translated.
+func (c LocaleCoverage) Complete() bool { return len(c.Missing) == 0 }
+
+// CheckCoverage cross-references the messages of the rules of a catalog
+// against locale catalogs, by locale, and returns their coverage sorted by
+// locale, e.g., to fail CI when a new code lands without translations:
+//
+//	for _, cov := range errdecode.CheckCoverage(catalog, locales) {
+//		if !cov.Complete() {
+//			log.Fatalf("%s: missing translations %q", cov.Locale, cov.Missing)
+//		}
+//	}
+func CheckCoverage(c *Catalog, locales map[string]Messages) []LocaleCoverage {
+	keys := make([]string, 0, len(c.Rules))
+	for _, r := range c.Rules {
+		keys = append(keys, r.Message)
+	}
+	return checkCoverage(keys, locales)
+}
+
+// Coverage is like CheckCoverage for the rules, including field rules, and
+// locale catalogs of the decoder, see LocaleMessages. Locales are reported
+// in lowercase.
+func (d *Decoder) Coverage() []LocaleCoverage {
+	keys := make([]string, 0, len(d.idx.rules)+len(d.fieldRules))
+	for _, r := range d.idx.rules {
+		keys = append(keys, r.Message)
+	}
+	for _, fr := range d.fieldRules {
+		keys = append(keys, fr.Message)
+	}
+
+	d.catalogs.mu.RLock()
+	locales := make(map[string]Messages, len(d.catalogs.byLocale))
+	for locale, msgs := range d.catalogs.byLocale {
+		locales[locale] = msgs
+	}
+	d.catalogs.mu.RUnlock()
+	return checkCoverage(keys, locales)
+}
+
+func checkCoverage(keys []string, locales map[string]Messages) []LocaleCoverage {
+	used := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key != "" {
+			used[key] = true
+		}
+	}
+
+	coverage := make([]LocaleCoverage, 0, len(locales))
+	for locale, msgs := range locales {
+		cov := LocaleCoverage{Locale: locale}
+		for key := range used {
+			if _, ok := msgs[key]; !ok {
+				cov.Missing = append(cov.Missing, key)
+			}
+		}
+		for key := range msgs {
+			if !used[key] {
+				cov.Orphaned = append(cov.Orphaned, key)
+			}
+		}
+		sort.Strings(cov.Missing)
+		sort.Strings(cov.Orphaned)
+		coverage = append(coverage, cov)
+	}
+	sort.Slice(coverage, func(i, j int) bool { return coverage[i].Locale < coverage[j].Locale })
+	return coverage
+}
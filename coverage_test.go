@@ -0,0 +1,50 @@
+package errdecode_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestCheckCoverage(t *testing.T) {
+	c, err := errdecode.ReadCatalog(strings.NewReader(`
+rules:
+  - {code: 1001, message: error.auth}
+  - {code: 1002, message: error.not_found}
+  - {code: 1003, message: error.not_found}
+  - {code: 1004}
+`), ".yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := errdecode.CheckCoverage(c, map[string]errdecode.Messages{
+		"fr": {"error.auth": "Non authentifié.", "error.not_found": "Introuvable."},
+		"de": {"error.auth": "Nicht authentifiziert.", "error.gone": "Entfernt."},
+	})
+	want := []errdecode.LocaleCoverage{
+		{Locale: "de", Missing: []string{"error.not_found"}, Orphaned: []string{"error.gone"}},
+		{Locale: "fr"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected coverage: got=%+v want=%+v", got, want)
+	}
+	if got[0].Complete() || !got[1].Complete() {
+		t.Fatalf("unexpected completeness: got=%t/%t want=false/true", got[0].Complete(), got[1].Complete())
+	}
+}
+
+func TestDecoderCoverage(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "error.auth"}},
+		errdecode.FieldRules(errdecode.FieldRule{Kind: "required", Code: 1002, Message: "error.required"}),
+		errdecode.LocaleMessages("fr-FR", errdecode.Messages{"error.auth": "Non authentifié."}),
+	)
+
+	got := dec.Coverage()
+	want := []errdecode.LocaleCoverage{{Locale: "fr-fr", Missing: []string{"error.required"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected coverage: got=%+v want=%+v", got, want)
+	}
+}
@@ -0,0 +1,31 @@
+package errdecode
+
+// Debug is used to start the decoder in debug mode, see SetDebug.
+func Debug() Option {
+	return func(d *Decoder) {
+		d.debug.Store(true)
+		d.options = append(d.options, "Debug")
+	}
+}
+
+// SetDebug enables or disables debug mode, in which the Error method of
+// classified errors appends the message of the underlying error to the
+// translated message, i.e., "friendly message: cause", and their JSON form
+// includes the cause, see WithCause. This lets developers see causes during
+// local debugging without changing call sites.
+//
+// The mode applies to errors already translated by the decoder as well. The
+// underlying error may leak implementation details, so debug mode should not
+// be enabled in production.
+func (d *Decoder) SetDebug(enabled bool) {
+	d.debug.Store(enabled)
+}
+
+// Debugging reports whether the decoder is in debug mode, see SetDebug.
+func (d *Decoder) Debugging() bool {
+	return d.debug.Load()
+}
+
+func (e *matchedError) debugging() bool {
+	return e.debug != nil && e.debug.Load() && e.err != nil
+}
@@ -0,0 +1,66 @@
+package errdecode_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestDebug(t *testing.T) {
+	rules := []errdecode.Rule{
+		{Code: codeClientError, Message: "Something went wrong.", Errors: []error{errClient1}},
+	}
+
+	tests := []struct {
+		name    string
+		options []errdecode.Option
+		debug   bool
+		want    string
+	}{
+		{"disabled", nil, false, "Something went wrong."},
+		{"enabled", []errdecode.Option{errdecode.Debug()}, true, "Something went wrong.: client error 1"},
+		{"lazy", []errdecode.Option{errdecode.Debug(), errdecode.Lazy()}, true, "Something went wrong.: client error 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := errdecode.New(rules, tt.options...)
+			if dec.Debugging() != tt.debug {
+				t.Fatalf("unexpected debug mode: got=%t want=%t", dec.Debugging(), tt.debug)
+			}
+			err := dec.Translate(errClient1)
+			if msg := err.Error(); msg != tt.want {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, tt.want)
+			}
+			if msg := errdecode.Render(err, ""); msg != "Something went wrong." {
+				t.Fatalf("unexpected rendered message: got='%s'", msg)
+			}
+		})
+	}
+}
+
+func TestSetDebug(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "Something went wrong.", Errors: []error{errClient1}},
+	})
+	err := dec.Translate(errClient1)
+
+	dec.SetDebug(true)
+	if msg := fmt.Sprint(err); msg != "Something went wrong.: client error 1" {
+		t.Fatalf("unexpected message: got='%s'", msg)
+	}
+	data, _ := json.Marshal(err)
+	if want := `{"code":1001,"message":"Something went wrong.","cause":"client error 1"}`; string(data) != want {
+		t.Fatalf("unexpected JSON: got=%s want=%s", data, want)
+	}
+	if msg := dec.NewError(codeClientError).Error(); msg != "Something went wrong." {
+		t.Fatalf("unexpected message of error without cause: got='%s'", msg)
+	}
+
+	dec.SetDebug(false)
+	if msg := err.Error(); msg != "Something went wrong." {
+		t.Fatalf("unexpected message: got='%s'", msg)
+	}
+}
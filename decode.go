@@ -1,5 +1,12 @@
 package errdecode
 
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
 // ClassifiedError describes the wrapped error value matched by the
 // classification rule set.
 type ClassifiedError interface {
@@ -10,6 +17,21 @@ type ClassifiedError interface {
 
 	// Unwrap returns the underlying error.
 	Unwrap() error
+
+	// Fields returns the structured context collected from the underlying
+	// error chain, see Fielder.
+	Fields() map[string]interface{}
+
+	// MessageKey returns the message of the matched rule, before it was
+	// translated.
+	MessageKey() string
+
+	// Retryable reports whether the matched rule marks the error as
+	// transient, i.e., the failed operation may succeed if retried.
+	Retryable() bool
+
+	// Severity returns the severity of the matched rule.
+	Severity() Severity
 }
 
 // Rule represents criteria for matching error values.
@@ -19,6 +41,9 @@ type Rule struct {
 
 	// Message describes the error class, e.g., a friendly explanation or
 	// a string identifier for key-based lookups.
+	//
+	// Once translated, "{name}" placeholders are replaced by the fields of
+	// the underlying error, see Fielder.
 	Message string
 
 	// Errors are values that fall under this classification.
@@ -27,6 +52,55 @@ type Rule struct {
 	// Match is a func that returns true if a given error is a match.
 	// It can be used to check error types by using a closure.
 	Match MatcherFunc
+
+	// Types are the concrete error types this rule can match, e.g.,
+	// reflect.TypeOf((*hex.InvalidByteError)(nil)).
+	//
+	// Errors whose wrap chain holds one of these types are matched, subject
+	// to Match if provided. Typed rules are dispatched by type, so their
+	// matchers are never evaluated against errors of other types.
+	Types []reflect.Type
+
+	// Retryable marks errors of this class as transient.
+	Retryable bool
+
+	// RetryAfter hints how long clients should wait before retrying errors
+	// of a retryable class. Zero means unspecified.
+	RetryAfter time.Duration
+
+	// Severity describes how serious errors of this class are.
+	Severity Severity
+
+	// HTTPStatus is the HTTP status code used when responding with errors
+	// of this class. Zero means unspecified.
+	HTTPStatus int
+
+	// GRPCCode is the gRPC status code, as defined by
+	// google.golang.org/grpc/codes, used when responding with errors of this
+	// class. Zero means unspecified.
+	GRPCCode int
+
+	// Tags are free-form labels for grouping rules, e.g., "auth".
+	Tags []string
+
+	// DocsURL links to documentation for errors of this class.
+	DocsURL string
+
+	// Priority orders classifications when several errors are aggregated,
+	// e.g., using errors.Join. Higher values take precedence.
+	Priority int
+
+	// Namespace is the owner of the code, e.g., a team or a service. Codes
+	// are checked against the range registered for the namespace, see
+	// RegisterRange.
+	Namespace string
+
+	// Deprecated marks the code as scheduled for removal. Errors are still
+	// classified by deprecated rules, see OnDeprecated.
+	Deprecated bool
+
+	// ReplacedBy is the code superseding a deprecated code, if any.
+	ReplacedBy int
 }
 
 // MatcherFunc describes an error matcher.
@@ -35,8 +109,24 @@ type MatcherFunc func(err error) (isMatch bool)
 // Decoder wraps a set of error translation rules, on which it provides
 // classication and translation of error values.
 type Decoder struct {
-	encoder       EncoderFunc
-	msgTranslator MessageTranslatorFunc
+	idx           *ruleIndex
+	encoder       ContextEncoderFunc
+	customEncoder bool // set by Encoder and EncoderContext
+	msgTranslator ContextMessageTranslatorFunc
+	fieldRules    map[fieldKey]FieldRule
+	extractors    map[int][]ExtractorFunc
+	withCause     bool
+	onDeprecated  DeprecationFunc
+	locales       []string
+	lazy          bool
+	escape        func(string) string
+	catalogs      *localeCatalogs
+	overrides     *overrides
+	onTranslate   []TranslationFunc
+	debug         *atomic.Bool
+	stats         *stats
+	options       []string // names of applied options, see Fingerprint
+	fingerprint   string
 }
 
 // EncoderFunc describes an error classifier, i.e., a function that converts
@@ -46,26 +136,208 @@ type EncoderFunc func(err error) (code int, message string)
 // MessageTranslatorFunc describes further transformations for decoded errors.
 type MessageTranslatorFunc func(decoded string) (translated string)
 
+// ContextEncoderFunc is like EncoderFunc, but has access to the context
+// passed to TranslateContext.
+type ContextEncoderFunc func(ctx context.Context, err error) (code int, message string)
+
+// ContextMessageTranslatorFunc is like MessageTranslatorFunc, but has access
+// to the context passed to TranslateContext, e.g., to look up the locale of
+// the request.
+type ContextMessageTranslatorFunc func(ctx context.Context, decoded string) (translated string)
+
+func (enc EncoderFunc) withContext() ContextEncoderFunc {
+	return func(_ context.Context, err error) (int, string) { return enc(err) }
+}
+
+func (t MessageTranslatorFunc) withContext() ContextMessageTranslatorFunc {
+	return func(_ context.Context, msg string) string { return t(msg) }
+}
+
 // New returns a configured error decoder.
+//
+// It panics if a rule code violates a range registered with RegisterRange.
 func New(rs []Rule, options ...Option) *Decoder {
+	if err := checkRanges(rs); err != nil {
+		panic(err)
+	}
+	idx := newRuleIndex(rs)
 	d := &Decoder{
-		encoder:       newDefaultEncoder(rs),
-		msgTranslator: defaultMessageTranslator,
+		idx:           idx,
+		stats:         newStats(idx),
+		encoder:       newDefaultEncoder(idx).withContext(),
+		msgTranslator: MessageTranslatorFunc(defaultMessageTranslator).withContext(),
+		catalogs:      &localeCatalogs{},
+		overrides:     &overrides{},
+		debug:         new(atomic.Bool),
 	}
 	for _, option := range options {
 		option(d)
 	}
+	d.fingerprint = newFingerprint(d.idx.rules, d.options)
 	return d
 }
 
 // Translate decodes an error value into a configured encoded mapping.
 // If the error cannot be classified, it is returned as-is.
+//
+// If the error aggregates several errors, e.g., using errors.Join, the
+// highest-priority classification among the aggregated errors is returned.
+// Only if none of them can be classified is the aggregate classified as a
+// whole.
 func (d *Decoder) Translate(err error) error {
-	code, msg := d.encoder(err)
+	return d.translate(context.Background(), err)
+}
+
+// TranslateContext is like Translate, but makes request-scoped data, e.g.,
+// the locale, tenant or request ID, available to the decoder, see
+// EncoderContext, MessageContext and Extractor. The classification is
+// recorded into the trace attached to ctx, if any, see WithTrace.
+//
+// Translate is equivalent to TranslateContext with context.Background.
+func (d *Decoder) TranslateContext(ctx context.Context, err error) error {
+	t, ok := TraceFromContext(ctx)
+	if !ok {
+		return d.translate(ctx, err)
+	}
+
+	start := time.Now()
+	translated := d.translate(ctx, err)
+	ev := TraceEvent{Start: start, Duration: time.Since(start), Err: err}
+	if ce, ok := translated.(ClassifiedError); ok {
+		ev.Code, ev.Message = ce.Code(), ce.MessageKey()
+	}
+	t.record(ev)
+	return translated
+}
+
+func (d *Decoder) translate(ctx context.Context, err error) error {
+	translated := err
+	if joined, ok := d.translateJoined(ctx, err); ok {
+		translated = joined
+	} else if decoded, ok := d.decode(ctx, err); ok {
+		d.warnDeprecated(ctx, decoded.Code(), decoded.Unwrap())
+		translated = decoded
+	}
+	if err == nil {
+		return translated
+	}
+	d.stats.record(translated)
+	for _, fn := range d.onTranslate {
+		fn(ctx, err, translated)
+	}
+	return translated
+}
+
+// TranslateCode classifies an error value like Translate, but returns the
+// code and translated message without allocating a wrapping error. It returns
+// false if the error cannot be classified.
+func (d *Decoder) TranslateCode(err error) (int, string, bool) {
+	ctx := context.Background()
+	if _, ok := err.(multiError); ok {
+		ce, ok := d.translate(ctx, err).(ClassifiedError)
+		if !ok {
+			return 0, "", false
+		}
+		return ce.Code(), ce.Error(), true
+	}
+
+	c, ok := d.classify(ctx, err)
+	d.stats.recordCode(c.code, ok)
+	if !ok {
+		return 0, "", false
+	}
+	d.warnDeprecated(ctx, c.code, c.err)
+	msg, _ := d.message(ctx, c)
+	return c.code, msg, true
+}
+
+// classification is the outcome of classifying an error value.
+type classification struct {
+	code  int
+	key   string
+	field string // set for field errors only
+	err   error
+}
+
+// classify classifies an error value as a whole.
+func (d *Decoder) classify(ctx context.Context, err error) (classification, bool) {
+	if fr, fe, ok := d.matchField(err); ok {
+		return classification{fr.Code, fr.Message, fe.Field(), err}, true
+	}
+	code, msg := d.encoder(ctx, err)
 	if code == 0 {
-		return err
+		return classification{}, false
+	}
+	return classification{code: code, key: msg, err: err}, true
+}
+
+// message translates the message of a classification and returns it along
+// with the fields it was expanded with.
+func (d *Decoder) message(ctx context.Context, c classification) (string, map[string]interface{}) {
+	fields := d.messageFields(ctx, c)
+	return d.render(ctx, c.code, c.key, fields), fields
+}
+
+// messageFields returns the fields a classification is expanded with.
+func (d *Decoder) messageFields(ctx context.Context, c classification) map[string]interface{} {
+	fields := collectFields(c.err)
+	if c.field != "" {
+		if fields == nil {
+			fields = make(map[string]interface{})
+		}
+		fields["field"] = c.field
+	}
+	return d.extract(ctx, c.code, fields)
+}
+
+// render translates the message key of a code, unless overridden, and
+// expands it with fields.
+func (d *Decoder) render(ctx context.Context, code int, key string, fields map[string]interface{}) string {
+	msg, locale, ok := d.override(ctx, code)
+	if !ok {
+		msg, locale = d.msgTranslator(ctx, key), d.messageLocale(ctx, key)
+	}
+	return expandFields(expandPlurals(msg, locale, fields), fields, d.escape)
+}
+
+// decode classifies an error value as a whole.
+func (d *Decoder) decode(ctx context.Context, err error) (ClassifiedError, bool) {
+	c, ok := d.classify(ctx, err)
+	if !ok {
+		return nil, false
+	}
+	me := d.newMatchedError(ctx, c)
+	if c.field != "" {
+		return &matchedFieldError{me, c.field}, true
 	}
-	return &matchedError{code, err, d.msgTranslator(msg)}
+	return me, true
+}
+
+// newMatchedError wraps a classified error value.
+func (d *Decoder) newMatchedError(ctx context.Context, c classification) *matchedError {
+	rule := d.idx.codeToRule[c.code]
+	var msg string
+	var fields map[string]interface{}
+	if d.lazy {
+		fields = d.messageFields(ctx, c)
+	} else {
+		msg, fields = d.message(ctx, c)
+	}
+	me := &matchedError{
+		code:      c.code,
+		err:       c.err,
+		msg:       msg,
+		key:       c.key,
+		fields:    fields,
+		retryable: rule.Retryable,
+		severity:  rule.Severity,
+		withCause: d.withCause,
+		debug:     d.debug,
+	}
+	if d.lazy {
+		me.lazy = &lazyMessage{decoder: d}
+	}
+	return me
 }
 
 // Compile-time check.
@@ -73,9 +345,16 @@ var _ ClassifiedError = (*matchedError)(nil)
 
 // Represents an error matched by the encoder.
 type matchedError struct {
-	code int
-	err  error
-	msg  string
+	code      int
+	err       error
+	msg       string
+	key       string
+	fields    map[string]interface{}
+	retryable bool
+	severity  Severity
+	withCause bool
+	lazy      *lazyMessage // nil unless translated by a lazy decoder
+	debug     *atomic.Bool // nil unless translated by a decoder
 }
 
 // Code satisfies ClassifiedError interface.
@@ -84,5 +363,37 @@ func (e *matchedError) Code() int { return e.code }
 // Unwrap satisfies ClassifiedError interface.
 func (e *matchedError) Unwrap() error { return e.err }
 
-// Error satisties the error interface.
-func (e *matchedError) Error() string { return e.msg }
+// Fields satisfies ClassifiedError interface.
+func (e *matchedError) Fields() map[string]interface{} { return e.fields }
+
+// MessageKey satisfies ClassifiedError interface.
+func (e *matchedError) MessageKey() string { return e.key }
+
+// Retryable satisfies ClassifiedError interface.
+func (e *matchedError) Retryable() bool { return e.retryable }
+
+// Severity satisfies ClassifiedError interface.
+func (e *matchedError) Severity() Severity { return e.severity }
+
+// Is reports whether target is a CodeTarget for the code of this error.
+func (e *matchedError) Is(target error) bool {
+	t, ok := target.(CodeTarget)
+	return ok && int(t) == e.code
+}
+
+// Error satisties the error interface. In debug mode, the message of the
+// underlying error is appended, see Debug.
+func (e *matchedError) Error() string {
+	if e.debugging() {
+		return e.message() + ": " + e.err.Error()
+	}
+	return e.message()
+}
+
+// message returns the translated message.
+func (e *matchedError) message() string {
+	if e.lazy != nil {
+		e.lazy.once.Do(func() { e.msg = e.Render("") })
+	}
+	return e.msg
+}
@@ -1,6 +1,7 @@
 package errdecode_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -29,6 +30,11 @@ func newCustomError(msg string) error {
 	return &err
 }
 
+// sliceError is a non-comparable error value.
+type sliceError struct{ msgs []string }
+
+func (e sliceError) Error() string { return fmt.Sprint(e.msgs) }
+
 func newDecoder() *errdecode.Decoder {
 	rules := []errdecode.Rule{
 		{
@@ -74,6 +80,7 @@ func TestDecoderTranslate(t *testing.T) {
 		{"second error value in group is matched", errClient2, codeClientError, "error.client"},
 		{"wrapped error value match", errWrappedError, codeWrappedError, "error.wrapped"},
 		{"custom error type match", newCustomError("custom error"), codeCustomError, "error.custom"},
+		{"non-comparable error value is unclassified", sliceError{[]string{"a"}}, 0, "[a]"},
 	}
 
 	dec := newDecoder()
@@ -125,6 +132,21 @@ func TestConfiguredCatchAll(t *testing.T) {
 	}
 }
 
+func TestSharedCodeMatchers(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	errRefused := errors.New("refused")
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeCatchAll, Message: "error.unavailable", Match: func(err error) bool { return errors.Is(err, errTimeout) }},
+		{Code: codeCatchAll, Message: "error.unavailable", Match: func(err error) bool { return errors.Is(err, errRefused) }},
+	})
+
+	for _, err := range []error{errTimeout, errRefused} {
+		if code := errdecode.Code(dec.Translate(err)); code != codeCatchAll {
+			t.Fatalf("unexpected code for %v: got=%d want=%d", err, code, codeCatchAll)
+		}
+	}
+}
+
 func TestClassifiedErrorUnwrapping(t *testing.T) {
 	dec := newDecoder()
 	err := dec.Translate(errClient1) // pre-configured in setup
@@ -215,3 +237,74 @@ func TestMessageTranslatorOption(t *testing.T) {
 		})
 	}
 }
+
+type tenantKey struct{}
+
+func TestContextOptions(t *testing.T) {
+	dec := errdecode.New(nil,
+		errdecode.EncoderContext(func(ctx context.Context, err error) (int, string) {
+			if ctx.Value(tenantKey{}) == "acme" && err == errClient1 {
+				return codeClientError, "error.client"
+			}
+			return 0, ""
+		}),
+		errdecode.MessageContext(func(ctx context.Context, msg string) string {
+			return fmt.Sprintf("%s (%v)", msg, ctx.Value(tenantKey{}))
+		}),
+	)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"context is passed to encoder and translator", dec.TranslateContext(ctx, errClient1), "error.client (acme)"},
+		{"translate uses an empty context", dec.Translate(errClient1), errClient1.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if msg := tt.err.Error(); msg != tt.want {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoderTranslateCode(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+		{Code: codeCustomError, Message: "error.custom", Errors: []error{errClient2}},
+	}, errdecode.Message(func(msg string) string { return "translated " + msg }))
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantMsg  string
+		wantOK   bool
+	}{
+		{"classified", errClient1, codeClientError, "translated error.client", true},
+		{"unclassified", errors.New("unmatched"), 0, "", false},
+		{"joined", errors.Join(errors.New("unmatched"), errClient2), codeCustomError, "translated error.custom", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, msg, ok := dec.TranslateCode(tt.err)
+			if code != tt.wantCode || msg != tt.wantMsg || ok != tt.wantOK {
+				t.Fatalf("unexpected result: got=(%d, '%s', %t) want=(%d, '%s', %t)",
+					code, msg, ok, tt.wantCode, tt.wantMsg, tt.wantOK)
+			}
+		})
+	}
+}
+
+func BenchmarkDecoderTranslateCode(b *testing.B) {
+	dec := newDecoder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dec.TranslateCode(errClient1)
+	}
+}
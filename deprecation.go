@@ -0,0 +1,31 @@
+package errdecode
+
+import "context"
+
+// DeprecationFunc is called when an error is classified by a deprecated
+// rule, see OnDeprecated.
+type DeprecationFunc func(ctx context.Context, rule Rule, err error)
+
+// OnDeprecated is used to be notified when an error is classified by a
+// deprecated rule, e.g., to log a warning or count clients still relying on
+// the code while they migrate to its replacement.
+//
+// The hook is called synchronously with the unclassified error, which is nil
+// for errors built by NewError, so it should be fast.
+func OnDeprecated(fn DeprecationFunc) Option {
+	return func(d *Decoder) {
+		d.onDeprecated = fn
+		d.options = append(d.options, "OnDeprecated")
+	}
+}
+
+// warnDeprecated calls the deprecation hook if the rule of the code is
+// deprecated.
+func (d *Decoder) warnDeprecated(ctx context.Context, code int, err error) {
+	if d.onDeprecated == nil {
+		return
+	}
+	if rule, ok := d.idx.codeToRule[code]; ok && rule.Deprecated {
+		d.onDeprecated(ctx, rule, err)
+	}
+}
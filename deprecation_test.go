@@ -0,0 +1,51 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestOnDeprecated(t *testing.T) {
+	errLegacy := errors.New("legacy")
+	errCurrent := errors.New("current")
+
+	var warned []errdecode.Rule
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.legacy", Errors: []error{errLegacy}, Deprecated: true, ReplacedBy: 1002},
+		{Code: 1002, Message: "error.current", Errors: []error{errCurrent}},
+	}, errdecode.OnDeprecated(func(_ context.Context, rule errdecode.Rule, err error) {
+		warned = append(warned, rule)
+	}))
+
+	tests := []struct {
+		name      string
+		translate func() error
+		want      []int
+	}{
+		{"deprecated", func() error { return dec.Translate(errLegacy) }, []int{1001}},
+		{"current", func() error { return dec.Translate(errCurrent) }, nil},
+		{"joined", func() error { return dec.Translate(errors.Join(errLegacy, errCurrent)) }, []int{1001}},
+		{"joined not picked", func() error { return dec.Translate(errors.Join(errCurrent, errLegacy)) }, nil},
+		{"all", func() error { dec.TranslateAll(errors.Join(errCurrent, errLegacy)); return nil }, []int{1001}},
+		{"code", func() error { dec.TranslateCode(errLegacy); return nil }, []int{1001}},
+		{"new error", func() error { return dec.NewError(1001) }, []int{1001}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warned = nil
+			tt.translate()
+			if len(warned) != len(tt.want) {
+				t.Fatalf("unexpected warnings: got=%d want=%d", len(warned), len(tt.want))
+			}
+			for i, rule := range warned {
+				if rule.Code != tt.want[i] || rule.ReplacedBy != 1002 {
+					t.Fatalf("unexpected rule: got=%d->%d want=%d->1002", rule.Code, rule.ReplacedBy, tt.want[i])
+				}
+			}
+		})
+	}
+}
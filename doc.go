@@ -126,4 +126,20 @@
 // 		}),
 // 	)
 //
+// Classified errors marshal to JSON like their ErrorPayload, the wire schema
+// described by the OpenAPI export:
+//
+//	{
+//		"code": 1001,
+//		"message": "The provided token is not valid.",
+//		"severity": "error",
+//		"details": {"client_id": "..."},
+//		"cause": "invalid token"
+//	}
+//
+// The details object holds the fields of the underlying error, see Fielder,
+// and is omitted if there are none. The cause is only included if the decoder
+// was configured with WithCause. The docs_url and correlation_id members are
+// only set by Decoder.Payload.
+//
 package errdecode
@@ -0,0 +1,38 @@
+package errdecode
+
+import (
+	"os"
+	"strconv"
+)
+
+// DefaultEnvPrefix is the prefix of the environment variables read by
+// EnvMessages when no prefix is given.
+const DefaultEnvPrefix = "ERRDECODE_MSG_"
+
+// EnvMessages is used to override rule messages with environment variables
+// named after the prefix and the code, e.g., ERRDECODE_MSG_1001, so wording
+// can be patched during incidents without changing the catalog.
+//
+// Overrides are read once, when the decoder is created, and only apply to
+// codes of the rule set. An empty prefix means DefaultEnvPrefix.
+func EnvMessages(prefix string) Option {
+	if prefix == "" {
+		prefix = DefaultEnvPrefix
+	}
+	return func(d *Decoder) {
+		for code, rule := range d.idx.codeToRule {
+			msg, ok := os.LookupEnv(prefix + strconv.Itoa(code))
+			if !ok {
+				continue
+			}
+			rule.Message = msg
+			d.idx.codeToRule[code] = rule
+			for i := range d.idx.rules {
+				if d.idx.rules[i].Code == code {
+					d.idx.rules[i].Message = msg
+				}
+			}
+		}
+		d.options = append(d.options, "EnvMessages")
+	}
+}
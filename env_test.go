@@ -0,0 +1,46 @@
+package errdecode_test
+
+import (
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestEnvMessages(t *testing.T) {
+	t.Setenv("ERRDECODE_MSG_1001", "Patched client message.")
+	t.Setenv("CUSTOM_1002", "Patched custom message.")
+	t.Setenv("ERRDECODE_MSG_1999", "Unknown code.")
+
+	rules := []errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+		{Code: codeCustomError, Message: "error.custom", Errors: []error{errClient2}},
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		err    error
+		want   string
+	}{
+		{"default prefix", "", errClient1, "Patched client message."},
+		{"default prefix ignores other variables", "", errClient2, "error.custom"},
+		{"custom prefix", "CUSTOM_", errClient2, "Patched custom message."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := errdecode.New(rules, errdecode.EnvMessages(tt.prefix))
+			if msg := dec.Translate(tt.err).Error(); msg != tt.want {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, tt.want)
+			}
+		})
+	}
+
+	dec := errdecode.New(rules, errdecode.EnvMessages(""))
+	if msg, _ := dec.MessageFor(codeClientError); msg != "Patched client message." {
+		t.Fatalf("expected override to be visible through introspection got='%s'", msg)
+	}
+	if rules[0].Message != "error.client" {
+		t.Fatalf("expected provided rules not to be modified")
+	}
+}
@@ -0,0 +1,66 @@
+// Package errtest provides assertion helpers for testing code that returns
+// errors classified by an errdecode.Decoder.
+//
+// The helpers walk the wrap chain of the asserted error, so tests can assert
+// on classification semantics rather than on rendered messages:
+//
+//	err := svc.Login(ctx, creds)
+//	errtest.RequireCode(t, err, 1001)
+//	errtest.RequireMessageKey(t, err, "error.auth")
+package errtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// RequireCode fails the test immediately unless err wraps a classified error
+// with the given code.
+func RequireCode(t testing.TB, err error, code int) {
+	t.Helper()
+	ce, ok := requireClassified(t, err)
+	if !ok {
+		return
+	}
+	if got := ce.Code(); got != code {
+		t.Fatalf("unexpected classification code: got=%d want=%d", got, code)
+	}
+}
+
+// RequireRetryable fails the test immediately unless err wraps a classified
+// error marked as retryable.
+func RequireRetryable(t testing.TB, err error) {
+	t.Helper()
+	ce, ok := requireClassified(t, err)
+	if !ok {
+		return
+	}
+	if !ce.Retryable() {
+		t.Fatalf("expected classification %d to be retryable", ce.Code())
+	}
+}
+
+// RequireMessageKey fails the test immediately unless err wraps a classified
+// error whose rule message, before translation, equals key.
+func RequireMessageKey(t testing.TB, err error, key string) {
+	t.Helper()
+	ce, ok := requireClassified(t, err)
+	if !ok {
+		return
+	}
+	if got := ce.MessageKey(); got != key {
+		t.Fatalf("unexpected message key: got='%s' want='%s'", got, key)
+	}
+}
+
+func requireClassified(t testing.TB, err error) (errdecode.ClassifiedError, bool) {
+	t.Helper()
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a classified error, got: %v", err)
+		return nil, false
+	}
+	return ce, true
+}
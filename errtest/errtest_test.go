@@ -0,0 +1,58 @@
+package errtest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/errtest"
+)
+
+var errTimeout = errors.New("timeout")
+var errInvalidToken = errors.New("invalid token")
+
+// recorder captures fatal failures instead of aborting the test.
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) { r.failed = true }
+
+func TestRequireHelpers(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.auth", Errors: []error{errInvalidToken}},
+		{Code: 1002, Message: "error.timeout", Errors: []error{errTimeout}, Retryable: true},
+	}, errdecode.Message(func(msg string) string { return "translated " + msg }))
+
+	auth := fmt.Errorf("handler: %w", dec.Translate(errInvalidToken))
+	timeout := dec.Translate(errTimeout)
+	unclassified := dec.Translate(errors.New("unmatched"))
+
+	tests := []struct {
+		name       string
+		assert     func(t testing.TB)
+		wantFailed bool
+	}{
+		{"code of wrapped classification", func(t testing.TB) { errtest.RequireCode(t, auth, 1001) }, false},
+		{"code mismatch", func(t testing.TB) { errtest.RequireCode(t, auth, 1002) }, true},
+		{"code of unclassified", func(t testing.TB) { errtest.RequireCode(t, unclassified, 1001) }, true},
+		{"retryable", func(t testing.TB) { errtest.RequireRetryable(t, timeout) }, false},
+		{"not retryable", func(t testing.TB) { errtest.RequireRetryable(t, auth) }, true},
+		{"message key ignores translation", func(t testing.TB) { errtest.RequireMessageKey(t, auth, "error.auth") }, false},
+		{"message key mismatch", func(t testing.TB) { errtest.RequireMessageKey(t, timeout, "error.auth") }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			tt.assert(r)
+			if r.failed != tt.wantFailed {
+				t.Fatalf("unexpected assertion result: failed=%t want=%t", r.failed, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package errdecode
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format is a documentation format for ExportCatalog.
+type Format int
+
+// Supported documentation formats.
+const (
+	FormatMarkdown Format = iota
+	FormatCSV
+)
+
+// ExportCatalog writes a table of the codes, translated messages, HTTP
+// statuses and documentation links of every rule, in ascending order of
+// code, e.g., to generate the error code page of API docs.
+func (d *Decoder) ExportCatalog(w io.Writer, format Format) error {
+	rules := d.Rules()
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Code < rules[j].Code })
+
+	rows := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		status := ""
+		if rule.HTTPStatus != 0 {
+			status = strconv.Itoa(rule.HTTPStatus)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(rule.Code),
+			d.msgTranslator(context.Background(), rule.Message),
+			status,
+			rule.DocsURL,
+		})
+	}
+	header := []string{"Code", "Message", "HTTP Status", "Docs"}
+
+	switch format {
+	case FormatMarkdown:
+		return writeMarkdownTable(w, header, rows)
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(header)
+		cw.WriteAll(rows)
+		return cw.Error()
+	default:
+		return fmt.Errorf("errdecode: unsupported format %d", format)
+	}
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+func writeMarkdownTable(w io.Writer, header []string, rows [][]string) error {
+	writeRow := func(cells []string) error {
+		escaped := make([]string, len(cells))
+		for i, c := range cells {
+			escaped[i] = markdownEscaper.Replace(c)
+		}
+		_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+		return err
+	}
+
+	if err := writeRow(header); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, strings.Repeat("| --- ", len(header))+"|\n"); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
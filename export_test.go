@@ -0,0 +1,56 @@
+package errdecode_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestDecoderExportCatalog(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1002, Message: "error.unavailable", HTTPStatus: 503},
+		{Code: 1001, Message: "error.auth", HTTPStatus: 401, DocsURL: "https://example.com/errors/1001"},
+	}, errdecode.Message(func(msg string) string {
+		if msg == "error.auth" {
+			return "Token invalid | expired."
+		}
+		return "Try again, later."
+	}))
+
+	tests := []struct {
+		name   string
+		format errdecode.Format
+		want   string
+	}{
+		{
+			"markdown", errdecode.FormatMarkdown,
+			"| Code | Message | HTTP Status | Docs |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| 1001 | Token invalid \\| expired. | 401 | https://example.com/errors/1001 |\n" +
+				"| 1002 | Try again, later. | 503 |  |\n",
+		},
+		{
+			"csv", errdecode.FormatCSV,
+			"Code,Message,HTTP Status,Docs\n" +
+				"1001,Token invalid | expired.,401,https://example.com/errors/1001\n" +
+				"1002,\"Try again, later.\",503,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := dec.ExportCatalog(&buf, tt.format); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("unexpected output:\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if err := dec.ExportCatalog(&bytes.Buffer{}, errdecode.Format(42)); err == nil {
+		t.Fatalf("expected unsupported format to be reported")
+	}
+}
@@ -0,0 +1,55 @@
+package errdecode
+
+import (
+	"expvar"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// expvars holds the decoder served by each variable published with
+// PublishExpvar.
+var expvars struct {
+	mu       sync.Mutex
+	decoders map[string]*atomic.Pointer[Decoder]
+}
+
+// PublishExpvar is used to publish the classification counts and the
+// fingerprint of the decoder as an expvar variable, so that they are served
+// by the /debug/vars endpoint, e.g.:
+//
+//	{"fingerprint": "3f2a…", "hits": {"1001": 12}, "unclassified": 3}
+//
+// The variable is published once: decoders later built with the same name,
+// e.g., on every reload of a Watcher, replace the decoder it serves. Like
+// expvar.Publish, it panics if name is already published by other code.
+func PublishExpvar(name string) Option {
+	return func(d *Decoder) {
+		expvars.mu.Lock()
+		p, ok := expvars.decoders[name]
+		if !ok {
+			p = new(atomic.Pointer[Decoder])
+			expvar.Publish(name, expvar.Func(func() interface{} { return p.Load().expvar() }))
+			if expvars.decoders == nil {
+				expvars.decoders = make(map[string]*atomic.Pointer[Decoder])
+			}
+			expvars.decoders[name] = p
+		}
+		p.Store(d)
+		expvars.mu.Unlock()
+		d.options = append(d.options, "PublishExpvar")
+	}
+}
+
+func (d *Decoder) expvar() interface{} {
+	stats := d.Stats()
+	hits := make(map[string]uint64, len(stats.Hits))
+	for code, n := range stats.Hits {
+		hits[strconv.Itoa(code)] = n
+	}
+	return map[string]interface{}{
+		"fingerprint":  d.fingerprint,
+		"hits":         hits,
+		"unclassified": stats.Unclassified,
+	}
+}
@@ -0,0 +1,56 @@
+package errdecode_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1, errClient2}},
+	}, errdecode.PublishExpvar("errdecode_test"))
+	dec.Translate(errClient1)
+	dec.Translate(errClient2)
+	dec.Translate(errUnclassified)
+
+	var got struct {
+		Fingerprint  string            `json:"fingerprint"`
+		Hits         map[string]uint64 `json:"hits"`
+		Unclassified uint64            `json:"unclassified"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get("errdecode_test").String()), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Fingerprint != dec.Fingerprint() {
+		t.Fatalf("unexpected fingerprint: got=%s want=%s", got.Fingerprint, dec.Fingerprint())
+	}
+	if got.Hits["1001"] != 2 {
+		t.Fatalf("unexpected hits: got=%d want=2", got.Hits["1001"])
+	}
+	if got.Unclassified != 1 {
+		t.Fatalf("unexpected unclassified count: got=%d want=1", got.Unclassified)
+	}
+}
+
+func TestPublishExpvarReload(t *testing.T) {
+	publish := errdecode.PublishExpvar("errdecode_test_reload")
+	errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+	}, publish)
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client_v2", Errors: []error{errClient1}},
+	}, publish)
+
+	var got struct {
+		Fingerprint string `json:"fingerprint"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get("errdecode_test_reload").String()), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Fingerprint != dec.Fingerprint() {
+		t.Fatalf("unexpected fingerprint: got=%s want=%s", got.Fingerprint, dec.Fingerprint())
+	}
+}
@@ -0,0 +1,45 @@
+package errdecode
+
+import "context"
+
+// ExtractorFunc derives structured context from a request context, e.g., the
+// client ID and token type of an authenticated request.
+//
+// Extracted values are exposed as fields of the classified error, so they
+// must be safe to log and return to clients: never extract credentials such
+// as the token itself.
+type ExtractorFunc func(ctx context.Context) (fields map[string]interface{})
+
+// Extractor is used to add request-scoped context to errors classified with
+// one of the given codes, e.g., auth-class errors.
+//
+// Extractors receive the context passed to TranslateContext, or
+// context.Background for errors translated with Translate. Fields of the
+// underlying error take precedence over extracted fields.
+func Extractor(fn ExtractorFunc, codes ...int) Option {
+	return func(d *Decoder) {
+		if d.extractors == nil {
+			d.extractors = make(map[int][]ExtractorFunc)
+		}
+		for _, code := range codes {
+			d.extractors[code] = append(d.extractors[code], fn)
+		}
+		d.options = append(d.options, "Extractor")
+	}
+}
+
+// extract merges the fields derived from ctx by the extractors registered for
+// code into fields.
+func (d *Decoder) extract(ctx context.Context, code int, fields map[string]interface{}) map[string]interface{} {
+	for _, fn := range d.extractors[code] {
+		for k, v := range fn(ctx) {
+			if fields == nil {
+				fields = make(map[string]interface{})
+			}
+			if _, exists := fields[k]; !exists {
+				fields[k] = v
+			}
+		}
+	}
+	return fields
+}
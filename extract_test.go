@@ -0,0 +1,43 @@
+package errdecode_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+type clientKey struct{}
+
+func TestExtractor(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+		{Code: codeCustomError, Message: "error.custom", Errors: []error{errClient2}},
+	}, errdecode.Extractor(func(ctx context.Context) map[string]interface{} {
+		clientID, _ := ctx.Value(clientKey{}).(string)
+		return map[string]interface{}{"client_id": clientID, "token_type": "bearer"}
+	}, codeClientError))
+
+	ctx := context.WithValue(context.Background(), clientKey{}, "client-42")
+	tests := []struct {
+		name string
+		err  error
+		want interface{}
+	}{
+		{"registered code is extracted", dec.TranslateContext(ctx, errClient1), "client-42"},
+		{"other codes are not extracted", dec.TranslateContext(ctx, errClient2), nil},
+		{"translate extracts from an empty context", dec.Translate(errClient1), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ce, ok := tt.err.(errdecode.ClassifiedError)
+			if !ok {
+				t.Fatalf("expected error to be classified")
+			}
+			if got := ce.Fields()["client_id"]; got != tt.want {
+				t.Fatalf("unexpected client_id: got=%v want=%v", got, tt.want)
+			}
+		})
+	}
+}
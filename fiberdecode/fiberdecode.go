@@ -0,0 +1,42 @@
+// Package fiberdecode adapts httpdecode to the Fiber web framework:
+//
+//	app := fiber.New(fiber.Config{
+//		ErrorHandler: fiberdecode.ErrorHandler(decoder),
+//	})
+package fiberdecode
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/httpdecode"
+)
+
+// ErrorHandler returns a Fiber error handler that translates errors with d,
+// using the user context of the request, and writes the same response as
+// httpdecode.Middleware.
+//
+// Errors the decoder cannot classify but that hold a *fiber.Error, e.g.,
+// fiber.ErrNotFound for unknown routes, are written with the status and
+// message of the Fiber error, and code 0.
+func ErrorHandler(d *errdecode.Decoder, options ...httpdecode.Option) fiber.ErrorHandler {
+	m := httpdecode.New(d, options...)
+	return func(c *fiber.Ctx, err error) error {
+		ctx := c.UserContext()
+		ce, status := m.Classify(ctx, err)
+		var fe *fiber.Error
+		if ce.Code() == 0 && errors.As(err, &fe) {
+			ce, status = errdecode.Classify(0, fe.Message), fe.Code
+		}
+
+		resp := m.RespondContext(ctx, ce, status)
+		for k, vs := range resp.Header {
+			// Set replaces Fiber's default Content-Type, Append keeps
+			// every other value of multi-valued headers.
+			c.Set(k, vs[0])
+			c.Append(k, vs[1:]...)
+		}
+		return c.Status(resp.Status).Send(resp.Body)
+	}
+}
@@ -0,0 +1,64 @@
+package fiberdecode_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/fiberdecode"
+)
+
+var (
+	errNotFound  = errors.New("order not found")
+	errUnhandled = errors.New("connection reset by peer")
+)
+
+func TestErrorHandler(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}, HTTPStatus: http.StatusNotFound},
+	})
+	app := fiber.New(fiber.Config{ErrorHandler: fiberdecode.ErrorHandler(dec)})
+	app.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(errdecode.WithCorrelationID(c.UserContext(), "req-1"))
+		return c.Next()
+	})
+	app.Get("/classified", func(c *fiber.Ctx) error { return errNotFound })
+	app.Get("/unclassified", func(c *fiber.Ctx) error { return errUnhandled })
+	app.Get("/fiber", func(c *fiber.Ctx) error { return fiber.NewError(fiber.StatusTeapot, "No coffee.") })
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"/classified", http.StatusNotFound, `{"code":1001,"message":"Order not found.","correlation_id":"req-1"}` + "\n"},
+		{"/unclassified", http.StatusInternalServerError, `{"code":0,"message":"Internal Server Error","correlation_id":"req-1"}` + "\n"},
+		{"/fiber", http.StatusTeapot, `{"code":0,"message":"No coffee.","correlation_id":"req-1"}` + "\n"},
+		{"/unknown", http.StatusNotFound, `{"code":0,"message":"Cannot GET /unknown","correlation_id":"req-1"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("unexpected status: got=%d want=%d", resp.StatusCode, tt.wantStatus)
+			}
+			if string(body) != tt.wantBody {
+				t.Fatalf("unexpected body: got=%s want=%s", body, tt.wantBody)
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+				t.Fatalf("unexpected content type: got=%s", ct)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package errdecode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FieldError is implemented by errors describing a failed validation of a
+// single input field, e.g., a missing email address.
+type FieldError interface {
+	error
+
+	// Field returns the name of the invalid field.
+	Field() string
+
+	// Kind returns the kind of failure, e.g., "required" or "too_short".
+	Kind() string
+}
+
+// NewFieldError returns a FieldError for the given field and failure kind.
+func NewFieldError(field, kind string) error {
+	return &fieldFailure{field, kind}
+}
+
+type fieldFailure struct {
+	field string
+	kind  string
+}
+
+func (e *fieldFailure) Field() string { return e.field }
+func (e *fieldFailure) Kind() string  { return e.kind }
+func (e *fieldFailure) Error() string { return e.field + ": " + e.kind }
+
+// FieldRule represents criteria for matching field errors.
+type FieldRule struct {
+	// Field is the name of the field. An empty field matches any field
+	// failing with the same kind.
+	Field string
+
+	// Kind is the kind of failure, see FieldError.
+	Kind string
+
+	// Code is an identifier for a class of field errors.
+	Code int
+
+	// Message describes the error class. The "{field}" placeholder is
+	// replaced by the name of the invalid field.
+	Message string
+}
+
+// ClassifiedFieldError describes a classified error for a single field.
+//
+// When field errors are aggregated, e.g., using errors.Join, TranslateAll
+// returns one ClassifiedFieldError per field.
+type ClassifiedFieldError interface {
+	ClassifiedError
+
+	// Field returns the name of the invalid field.
+	Field() string
+}
+
+// FieldRules is used to classify field errors, see FieldError.
+//
+// Field rules are checked before any other rule. A rule for a specific field
+// takes precedence over a rule for any field with the same kind.
+func FieldRules(frs ...FieldRule) Option {
+	return func(d *Decoder) {
+		if d.fieldRules == nil {
+			d.fieldRules = make(map[fieldKey]FieldRule)
+		}
+		for _, fr := range frs {
+			d.fieldRules[fieldKey{fr.Field, fr.Kind}] = fr
+		}
+		d.options = append(d.options, fmt.Sprintf("FieldRules%v", frs))
+	}
+}
+
+type fieldKey struct {
+	field string
+	kind  string
+}
+
+// matchField finds the field rule for err if it wraps a field error.
+func (d *Decoder) matchField(err error) (FieldRule, FieldError, bool) {
+	if len(d.fieldRules) == 0 {
+		return FieldRule{}, nil, false
+	}
+	fe, ok := asFieldError(err)
+	if !ok {
+		return FieldRule{}, nil, false
+	}
+
+	fr, ok := d.fieldRules[fieldKey{fe.Field(), fe.Kind()}]
+	if !ok {
+		fr, ok = d.fieldRules[fieldKey{"", fe.Kind()}]
+	}
+	return fr, fe, ok
+}
+
+// asFieldError finds the first field error in the wrap chain of err. Unlike
+// errors.As, it does not descend into aggregates, so that aggregated field
+// errors are classified one by one.
+func asFieldError(err error) (FieldError, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if fe, ok := err.(FieldError); ok {
+			return fe, true
+		}
+	}
+	return nil, false
+}
+
+// Compile-time check.
+var _ ClassifiedFieldError = (*matchedFieldError)(nil)
+
+// Represents a field error matched by a field rule.
+type matchedFieldError struct {
+	*matchedError
+	field string
+}
+
+// Field satisfies ClassifiedFieldError interface.
+func (e *matchedFieldError) Field() string { return e.field }
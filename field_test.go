@@ -0,0 +1,73 @@
+package errdecode_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func newFieldDecoder() *errdecode.Decoder {
+	return errdecode.New(nil, errdecode.FieldRules(
+		errdecode.FieldRule{Kind: "required", Code: 2001, Message: "{field}: required"},
+		errdecode.FieldRule{Field: "password", Kind: "too_short", Code: 2002, Message: "{field}: too short"},
+		errdecode.FieldRule{Field: "email", Kind: "required", Code: 2003, Message: "An email is required."},
+	))
+}
+
+func TestFieldErrorTranslate(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCode  int
+		wantField string
+		wantMsg   string
+	}{
+		{"any field rule", errdecode.NewFieldError("name", "required"), 2001, "name", "name: required"},
+		{"specific field rule", errdecode.NewFieldError("password", "too_short"), 2002, "password", "password: too short"},
+		{"specific field rule takes precedence", errdecode.NewFieldError("email", "required"), 2003, "email", "An email is required."},
+		{"wrapped field error", fmt.Errorf("signup: %w", errdecode.NewFieldError("name", "required")), 2001, "name", "name: required"},
+	}
+
+	dec := newFieldDecoder()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			fe, ok := err.(errdecode.ClassifiedFieldError)
+			if !ok {
+				t.Fatalf("expected a classified field error, got: %v", err)
+			}
+			if fe.Code() != tt.wantCode || fe.Field() != tt.wantField || fe.Error() != tt.wantMsg {
+				t.Fatalf("unexpected field error: got=(%d, %s, '%s') want=(%d, %s, '%s')",
+					fe.Code(), fe.Field(), fe.Error(), tt.wantCode, tt.wantField, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestFieldErrorUnmatched(t *testing.T) {
+	errUnmatched := errdecode.NewFieldError("password", "pwned")
+	if err := newFieldDecoder().Translate(errUnmatched); err != errUnmatched {
+		t.Fatalf("expected unmatched field error to be returned as-is, got: %v", err)
+	}
+}
+
+func TestFieldErrorTranslateAll(t *testing.T) {
+	err := errors.Join(
+		errdecode.NewFieldError("email", "required"),
+		errdecode.NewFieldError("password", "too_short"),
+	)
+
+	errs := newFieldDecoder().TranslateAll(err)
+	want := []string{"email", "password"}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected number of errors: got=%d want=%d", len(errs), len(want))
+	}
+	for i, err := range errs {
+		var fe errdecode.ClassifiedFieldError
+		if !errors.As(err, &fe) || fe.Field() != want[i] {
+			t.Fatalf("unexpected field error at %d: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package errdecode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Fielder is implemented by errors that carry structured context, e.g., the
+// ID of a resource that could not be found.
+type Fielder interface {
+	Fields() map[string]interface{}
+}
+
+// collectFields walks the wrap chain of err and merges the fields of every
+// Fielder encountered. Fields of outer errors take precedence over those of
+// the errors they wrap.
+func collectFields(err error) map[string]interface{} {
+	var fields map[string]interface{}
+	for ; err != nil; err = errors.Unwrap(err) {
+		f, ok := err.(Fielder)
+		if !ok {
+			continue
+		}
+		for k, v := range f.Fields() {
+			if fields == nil {
+				fields = make(map[string]interface{})
+			}
+			if _, exists := fields[k]; !exists {
+				fields[k] = v
+			}
+		}
+	}
+	return fields
+}
+
+// EscapeFields is used to escape the values of fields before they are
+// expanded into messages, e.g., with html.EscapeString when messages are
+// rendered in HTML pages, so that values taken from errors cannot inject
+// markup. Messages themselves, and their translations, are not escaped.
+func EscapeFields(escape func(string) string) Option {
+	return func(d *Decoder) {
+		d.escape = escape
+		d.options = append(d.options, "EscapeFields")
+	}
+}
+
+// expandFields replaces "{name}" placeholders in msg with the matching field
+// values, escaped with escape if not nil. Placeholders without a matching
+// field are left untouched.
+func expandFields(msg string, fields map[string]interface{}, escape func(string) string) string {
+	if len(fields) == 0 || !strings.Contains(msg, "{") {
+		return msg
+	}
+	pairs := make([]string, 0, len(fields)*2)
+	for k, v := range fields {
+		value := fmt.Sprint(v)
+		if escape != nil {
+			value = escape(value)
+		}
+		pairs = append(pairs, "{"+k+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
@@ -0,0 +1,103 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+type notFoundError struct {
+	kind string
+	id   int
+}
+
+func (e *notFoundError) Error() string { return fmt.Sprintf("%s %d not found", e.kind, e.id) }
+
+func (e *notFoundError) Fields() map[string]interface{} {
+	return map[string]interface{}{"kind": e.kind, "id": e.id}
+}
+
+type annotatedError struct {
+	err  error
+	kind string
+}
+
+func (e *annotatedError) Error() string { return e.err.Error() }
+func (e *annotatedError) Unwrap() error { return e.err }
+
+func (e *annotatedError) Fields() map[string]interface{} {
+	return map[string]interface{}{"kind": e.kind}
+}
+
+func TestClassifiedErrorFields(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{{
+		Code:    codeCustomError,
+		Message: "The {kind} {id} does not exist.",
+		Match: func(err error) bool {
+			var nf *notFoundError
+			return errors.As(err, &nf)
+		},
+	}})
+
+	tests := []struct {
+		name    string
+		err     error
+		wantMsg string
+		wantID  interface{}
+	}{
+		{"fields are interpolated", &notFoundError{"user", 42}, "The user 42 does not exist.", 42},
+		{"wrapped fields are collected", fmt.Errorf("lookup: %w", &notFoundError{"order", 7}), "The order 7 does not exist.", 7},
+		{"outer fields take precedence", &annotatedError{&notFoundError{"order", 7}, "invoice"}, "The invoice 7 does not exist.", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if msg := err.Error(); msg != tt.wantMsg {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, tt.wantMsg)
+			}
+			ce, ok := err.(errdecode.ClassifiedError)
+			if !ok {
+				t.Fatalf("expected error to be classified")
+			}
+			if id := ce.Fields()["id"]; id != tt.wantID {
+				t.Fatalf("unexpected field: got=%v want=%v", id, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestClassifiedErrorWithoutFields(t *testing.T) {
+	err := newDecoder().Translate(errClient1)
+	ce, ok := err.(errdecode.ClassifiedError)
+	if !ok {
+		t.Fatalf("expected error to be classified")
+	}
+	if fields := ce.Fields(); fields != nil {
+		t.Fatalf("expected no fields got=%v", fields)
+	}
+}
+
+func TestEscapeFields(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{{
+		Code:    codeCustomError,
+		Message: "error.not_found",
+		Match: func(err error) bool {
+			var nf *notFoundError
+			return errors.As(err, &nf)
+		},
+	}},
+		errdecode.EscapeFields(html.EscapeString),
+		errdecode.LocaleMessages("fr", errdecode.Messages{"error.not_found": "L'objet <b>{kind}</b> {id} n'existe pas."}),
+	)
+
+	ctx := errdecode.WithLocale(context.Background(), "fr")
+	err := dec.TranslateContext(ctx, &notFoundError{`<script>alert("x")</script>`, 42})
+	if got, want := err.Error(), "L'objet <b>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</b> 42 n'existe pas."; got != want {
+		t.Fatalf("unexpected message: got=%s want=%s", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+package errdecode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Fingerprint returns a digest of the compiled rule set and the options the
+// decoder was configured with.
+//
+// Two decoders built from the same catalog produce the same fingerprint, so
+// it can be logged at startup, see LogValue, or published on a debug
+// endpoint, see PublishExpvar, to verify that every instance of a service is
+// running the same error catalog version. Rules are hashed in order, since
+// their order decides which of several rules classifies an error.
+//
+// Functions cannot be hashed, so matchers and options only contribute their
+// presence, not their behavior.
+func (d *Decoder) Fingerprint() string { return d.fingerprint }
+
+// LogValue satisfies the slog.LogValuer interface, so that the fingerprint
+// and the number of rules of the decoder can be logged at startup:
+//
+//	slog.Info("error catalog loaded", "decoder", decoder)
+func (d *Decoder) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("fingerprint", d.fingerprint),
+		slog.Int("rules", len(d.idx.rules)),
+	)
+}
+
+// newFingerprint hashes the rules in order, followed by the names of the
+// options that were applied.
+func newFingerprint(rs []Rule, options []string) string {
+	h := sha256.New()
+	for _, rule := range rs {
+		fmt.Fprintf(h, "code=%d\nmessage=%q\nmatch=%t\n", rule.Code, rule.Message, rule.Match != nil)
+		fmt.Fprintf(h, "retryable=%t\npriority=%d\ntypes=%v\n", rule.Retryable, rule.Priority, rule.Types)
+		fmt.Fprintf(h, "severity=%s\nhttp_status=%d\ntags=%q\ndocs_url=%q\nnamespace=%q\n", rule.Severity, rule.HTTPStatus, rule.Tags, rule.DocsURL, rule.Namespace)
+		fmt.Fprintf(h, "grpc_code=%d\nretry_after=%s\n", rule.GRPCCode, rule.RetryAfter)
+		fmt.Fprintf(h, "deprecated=%t\nreplaced_by=%d\n", rule.Deprecated, rule.ReplacedBy)
+		for _, e := range rule.Errors {
+			if e == nil {
+				continue
+			}
+			fmt.Fprintf(h, "error=%T:%q\n", e, e.Error())
+		}
+	}
+	for _, name := range options {
+		io.WriteString(h, "option="+name+"\n")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
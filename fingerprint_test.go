@@ -0,0 +1,72 @@
+package errdecode_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestDecoderFingerprint(t *testing.T) {
+	rules := []errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+		{Code: codeCatchAll, Message: "error.catchall", Match: func(_ error) bool { return true }},
+	}
+	reordered := []errdecode.Rule{rules[1], rules[0]}
+	reworded := []errdecode.Rule{
+		{Code: codeClientError, Message: "error.client_v2", Errors: []error{errClient1}},
+		rules[1],
+	}
+	identity := errdecode.Message(func(msg string) string { return msg })
+
+	fp := errdecode.New(rules).Fingerprint()
+	if fp == "" {
+		t.Fatalf("expected a non-empty fingerprint")
+	}
+	if got := errdecode.New(rules).Fingerprint(); got != fp {
+		t.Fatalf("unexpected fingerprint of the same catalog: got=%s want=%s", got, fp)
+	}
+	if got := errdecode.New(reordered).Fingerprint(); got == fp {
+		t.Fatalf("expected rule order to change the fingerprint")
+	}
+	if got := errdecode.New(reworded).Fingerprint(); got == fp {
+		t.Fatalf("expected reworded catalog to change the fingerprint")
+	}
+	if got := errdecode.New(rules, identity).Fingerprint(); got == fp {
+		t.Fatalf("expected applied options to change the fingerprint")
+	}
+	if got := errdecode.New([]errdecode.Rule{{Code: 1, Errors: []error{errors.New("a")}}}).Fingerprint(); got == fp {
+		t.Fatalf("expected a different catalog to change the fingerprint")
+	}
+}
+
+func TestDecoderFingerprintNilError(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{{Code: codeClientError, Message: "error.client", Errors: []error{nil, errClient1}}})
+	if dec.Fingerprint() == "" {
+		t.Fatalf("expected a non-empty fingerprint")
+	}
+}
+
+func TestDecoderLogValue(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+	})
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("error catalog loaded", "decoder", dec)
+
+	var got struct {
+		Decoder struct {
+			Fingerprint string `json:"fingerprint"`
+			Rules       int    `json:"rules"`
+		} `json:"decoder"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Decoder.Fingerprint != dec.Fingerprint() || got.Decoder.Rules != 1 {
+		t.Fatalf("unexpected decoder: got=%+v want=%s", got.Decoder, dec.Fingerprint())
+	}
+}
@@ -0,0 +1,41 @@
+package errdecode
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Format satisfies the fmt.Formatter interface.
+//
+// The %s and %v verbs print the translated message, %q prints it quoted.
+// The %+v verb prints the code and message followed by the underlying cause
+// chain, one error per line. Causes implementing fmt.Formatter themselves,
+// e.g., to print a captured stack trace, are formatted with %+v. The %x and
+// %X verbs print the message in hexadecimal, like for other errors, and other
+// verbs print the bad verb form of package fmt, e.g., %!d(...).
+func (e *matchedError) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%d: %s", e.code, e.message())
+			for err := e.err; err != nil; err = errors.Unwrap(err) {
+				if _, ok := err.(fmt.Formatter); ok {
+					fmt.Fprintf(f, "\ncaused by: %+v", err)
+					return
+				}
+				fmt.Fprintf(f, "\ncaused by: %T: %s", err, err)
+			}
+			return
+		}
+		io.WriteString(f, e.Error())
+	case 's':
+		io.WriteString(f, e.Error())
+	case 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	case 'x', 'X':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), e.Error())
+	default:
+		fmt.Fprintf(f, "%%!%c(%T=%s)", verb, e, e.Error())
+	}
+}
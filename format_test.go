@@ -0,0 +1,56 @@
+package errdecode_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+type stackError struct{ msg string }
+
+func (e *stackError) Error() string { return e.msg }
+
+func (e *stackError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "%s\n\tmain.go:42", e.msg)
+		return
+	}
+	fmt.Fprint(f, e.msg)
+}
+
+func TestClassifiedErrorFormat(t *testing.T) {
+	dec := newDecoder()
+	tests := []struct {
+		name   string
+		format string
+		err    error
+		want   string
+	}{
+		{"%s prints message", "%s", dec.Translate(errClient1), "error.client"},
+		{"%v prints message", "%v", dec.Translate(errClient1), "error.client"},
+		{"%q quotes message", "%q", dec.Translate(errClient1), `"error.client"`},
+		{"%x prints message in hexadecimal", "%x", dec.Translate(errClient1), "6572726f722e636c69656e74"},
+		{"% X prints message in spaced hexadecimal", "% X", dec.Translate(errClient1), "65 72 72 6F 72 2E 63 6C 69 65 6E 74"},
+		{"%d prints bad verb", "%d", dec.Translate(errClient1), "%!d(*errdecode.matchedError=error.client)"},
+		{"%+v prints cause", "%+v", dec.Translate(errClient1), "1001: error.client\ncaused by: *errors.errorString: client error 1"},
+		{
+			"%+v prints cause chain", "%+v",
+			dec.Translate(fmt.Errorf("wrapped: %w", newCustomError("custom"))),
+			"1002: error.custom\ncaused by: *fmt.wrapError: wrapped: custom\ncaused by: *errdecode_test.CustomError: custom",
+		},
+		{
+			"%+v formats causes", "%+v",
+			errdecode.New([]errdecode.Rule{{Code: codeCatchAll, Message: "error.catchall", Match: func(_ error) bool { return true }}}).Translate(fmt.Errorf("wrapped: %w", &stackError{"stack"})),
+			"1000: error.catchall\ncaused by: *fmt.wrapError: wrapped: stack\ncaused by: stack\n\tmain.go:42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.err); got != tt.want {
+				t.Fatalf("unexpected output:\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package errdecode
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadPO reads the catalog of the locale lang from a gettext PO file, with
+// the messages of rules as msgid, and returns the option attaching it to a
+// decoder, see LocaleMessages:
+//
+//	msgid "error.not_found"
+//	msgstr "Commande introuvable."
+//
+// Untranslated and fuzzy entries are ignored, as well as entries with a
+// context or plural forms, since plural forms are selected with the plural
+// syntax of translations, see LocaleMessages.
+func LoadPO(lang string, r io.Reader) (Option, error) {
+	msgs, err := readPO(r)
+	if err != nil {
+		return nil, fmt.Errorf("errdecode: decode %s PO catalog: %w", lang, err)
+	}
+	return LocaleMessages(lang, msgs), nil
+}
+
+// LoadMO is like LoadPO, but reads a compiled gettext MO file.
+func LoadMO(lang string, r io.Reader) (Option, error) {
+	msgs, err := readMO(r)
+	if err != nil {
+		return nil, fmt.Errorf("errdecode: decode %s MO catalog: %w", lang, err)
+	}
+	return LocaleMessages(lang, msgs), nil
+}
+
+// poEntry is an entry of a PO file being read.
+type poEntry struct {
+	fuzzy, skip bool
+	id, str     *string // the string being continued points to one of them
+	cur         *string
+}
+
+func readPO(r io.Reader) (Messages, error) {
+	msgs := make(Messages)
+	var e poEntry
+	flush := func() {
+		if e.id != nil && e.str != nil && *e.id != "" && *e.str != "" && !e.fuzzy && !e.skip {
+			msgs[*e.id] = *e.str
+		}
+		e = poEntry{}
+	}
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#,"):
+			if e.id != nil {
+				flush()
+			}
+			e.fuzzy = strings.Contains(line, "fuzzy")
+			continue
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, `"`):
+			if e.cur == nil {
+				return nil, fmt.Errorf("line %d: unexpected string", n)
+			}
+			s, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid string %s", n, line)
+			}
+			*e.cur += s
+			continue
+		}
+
+		keyword, value, _ := strings.Cut(line, " ")
+		s, err := strconv.Unquote(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid string %s", n, value)
+		}
+		switch {
+		case keyword == "msgctxt":
+			if e.id != nil {
+				flush()
+			}
+			e.skip, e.cur = true, new(string)
+		case keyword == "msgid":
+			if e.id != nil {
+				flush()
+			}
+			e.id, e.cur = &s, &s
+		case keyword == "msgid_plural" || strings.HasPrefix(keyword, "msgstr["):
+			e.skip, e.cur = true, new(string)
+		case keyword == "msgstr":
+			if e.id == nil {
+				return nil, fmt.Errorf("line %d: msgstr without msgid", n)
+			}
+			e.str, e.cur = &s, &s
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %q", n, keyword)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return msgs, nil
+}
+
+var errInvalidMO = errors.New("invalid MO file")
+
+func readMO(r io.Reader) (Messages, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 20 {
+		return nil, errInvalidMO
+	}
+	var order binary.ByteOrder
+	switch binary.LittleEndian.Uint32(data) {
+	case 0x950412de:
+		order = binary.LittleEndian
+	case 0xde120495:
+		order = binary.BigEndian
+	default:
+		return nil, errInvalidMO
+	}
+	n := order.Uint32(data[8:])
+	originals, translations := order.Uint32(data[12:]), order.Uint32(data[16:])
+
+	str := func(table, i uint32) ([]byte, error) {
+		entry := uint64(table) + uint64(i)*8
+		if entry+8 > uint64(len(data)) {
+			return nil, errInvalidMO
+		}
+		length, offset := uint64(order.Uint32(data[entry:])), uint64(order.Uint32(data[entry+4:]))
+		if offset+length > uint64(len(data)) {
+			return nil, errInvalidMO
+		}
+		return data[offset : offset+length], nil
+	}
+
+	msgs := make(Messages)
+	for i := uint32(0); i < n; i++ {
+		id, err := str(originals, i)
+		if err != nil {
+			return nil, err
+		}
+		translation, err := str(translations, i)
+		if err != nil {
+			return nil, err
+		}
+		// Skip the header, entries with a context and plural forms.
+		if len(id) == 0 || len(translation) == 0 || bytes.IndexByte(id, 4) >= 0 || bytes.IndexByte(id, 0) >= 0 {
+			continue
+		}
+		msgs[string(id)] = string(translation)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,153 @@
+package errdecode_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+const poCatalog = `# French translations.
+msgid ""
+msgstr ""
+"Language: fr\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#: rules.yaml:3
+msgid "error.not_found"
+msgstr "Commande "
+"introuvable."
+
+msgid "Access denied."
+msgstr "Accès \"refusé\"."
+
+#, fuzzy
+msgid "error.quota"
+msgstr "Quota dépassé."
+
+msgid "error.untranslated"
+msgstr ""
+
+msgctxt "button"
+msgid "error.not_found"
+msgstr "Introuvable"
+
+msgid "error.files"
+msgid_plural "error.files"
+msgstr[0] "Fichier"
+msgstr[1] "Fichiers"
+`
+
+func TestLoadPO(t *testing.T) {
+	fr, err := errdecode.LoadPO("fr", strings.NewReader(poCatalog))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testGettext(t, fr)
+}
+
+func TestLoadPOErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		po   string
+	}{
+		{"unquoted string", "msgid error.not_found\nmsgstr \"Introuvable.\""},
+		{"unexpected string", "\"Introuvable.\""},
+		{"msgstr without msgid", "msgstr \"Introuvable.\""},
+		{"unknown keyword", "msgid \"error.not_found\"\nmsgtxt \"Introuvable.\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := errdecode.LoadPO("fr", strings.NewReader(tt.po)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+// mo compiles pairs of message IDs and translations into an MO file.
+func mo(pairs ...string) []byte {
+	n := len(pairs) / 2
+	var strs bytes.Buffer
+	offset := 28 + n*16
+	tables := make([]uint32, 0, n*4)
+	for _, s := range pairs {
+		tables = append(tables, uint32(len(s)), uint32(offset+strs.Len()))
+		strs.WriteString(s)
+		strs.WriteByte(0)
+	}
+	var originals, translations []uint32
+	for i := 0; i < n; i++ {
+		originals = append(originals, tables[i*4:i*4+2]...)
+		translations = append(translations, tables[i*4+2:i*4+4]...)
+	}
+
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, []uint32{0x950412de, 0, uint32(n), 28, uint32(28 + n*8), 0, 0})
+	binary.Write(&b, binary.LittleEndian, originals)
+	binary.Write(&b, binary.LittleEndian, translations)
+	b.Write(strs.Bytes())
+	return b.Bytes()
+}
+
+func TestLoadMO(t *testing.T) {
+	fr, err := errdecode.LoadMO("fr", bytes.NewReader(mo(
+		"", "Language: fr\n",
+		"error.not_found", "Commande introuvable.",
+		"Access denied.", `Accès "refusé".`,
+		"button\x04error.quota", "Quota",
+		"error.files\x00error.files", "Fichier\x00Fichiers",
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testGettext(t, fr)
+}
+
+func TestLoadMOErrors(t *testing.T) {
+	valid := mo("error.not_found", "Commande introuvable.")
+	tests := []struct {
+		name string
+		mo   []byte
+	}{
+		{"empty", nil},
+		{"bad magic", append([]byte{1, 2, 3, 4}, valid[4:]...)},
+		{"truncated", valid[:40]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := errdecode.LoadMO("fr", bytes.NewReader(tt.mo)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func testGettext(t *testing.T, fr errdecode.Option) {
+	t.Helper()
+	errNotFound, errDenied, errQuota, errFiles := errors.New("not found"), errors.New("denied"), errors.New("quota"), errors.New("files")
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.not_found", Errors: []error{errNotFound}},
+		{Code: 1002, Message: "Access denied.", Errors: []error{errDenied}},
+		{Code: 1003, Message: "error.quota", Errors: []error{errQuota}},
+		{Code: 1004, Message: "error.files", Errors: []error{errFiles}},
+	}, fr)
+
+	ctx := errdecode.WithLocale(context.Background(), "fr")
+	for err, want := range map[error]string{
+		errNotFound: "Commande introuvable.",
+		errDenied:   `Accès "refusé".`,
+		errQuota:    "error.quota",
+		errFiles:    "error.files",
+	} {
+		if got := dec.TranslateContext(ctx, err).Error(); got != want {
+			t.Fatalf("unexpected message: got=%s want=%s", got, want)
+		}
+	}
+}
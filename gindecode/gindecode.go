@@ -0,0 +1,56 @@
+// Package gindecode adapts httpdecode to the Gin web framework.
+//
+// Handlers record errors with c.Error and the middleware writes the response:
+//
+//	r := gin.New()
+//	r.Use(gindecode.Middleware(decoder))
+//	r.GET("/orders/:id", func(c *gin.Context) {
+//		order, err := store.Find(c.Request.Context(), c.Param("id"))
+//		if err != nil {
+//			c.Error(err)
+//			return
+//		}
+//		c.JSON(http.StatusOK, order)
+//	})
+package gindecode
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/httpdecode"
+)
+
+// Middleware returns a Gin middleware that translates the errors recorded
+// by handlers in c.Errors with d, and writes the same response as
+// httpdecode.Middleware.
+//
+// If several errors were recorded, they are translated as an aggregate, so
+// the highest-priority classification is written, see Rule.Priority.
+// Nothing is written if the handler already wrote a response.
+func Middleware(d *errdecode.Decoder, options ...httpdecode.Option) gin.HandlerFunc {
+	m := httpdecode.New(d, options...)
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		errs := make([]error, len(c.Errors))
+		for i, e := range c.Errors {
+			errs[i] = e.Err
+		}
+		err := errs[0]
+		if len(errs) > 1 {
+			err = errors.Join(errs...)
+		}
+
+		resp := m.Response(c.Request.Context(), err)
+		for k, vs := range resp.Header {
+			c.Writer.Header()[k] = vs
+		}
+		c.Status(resp.Status)
+		c.Writer.Write(resp.Body)
+	}
+}
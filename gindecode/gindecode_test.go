@@ -0,0 +1,80 @@
+package gindecode_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/gindecode"
+)
+
+var (
+	errNotFound = errors.New("order not found")
+	errInvalid  = errors.New("invalid order")
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}, HTTPStatus: http.StatusNotFound},
+		{Code: 1002, Message: "Invalid order.", Errors: []error{errInvalid}, HTTPStatus: http.StatusBadRequest, Priority: 1},
+	})
+
+	tests := []struct {
+		name       string
+		handler    gin.HandlerFunc
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "no error",
+			handler:    func(c *gin.Context) { c.String(http.StatusOK, "ok") },
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:       "error",
+			handler:    func(c *gin.Context) { c.Error(errNotFound) },
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code":1001,"message":"Order not found."}` + "\n",
+		},
+		{
+			name: "several errors",
+			handler: func(c *gin.Context) {
+				c.Error(errNotFound)
+				c.Error(errInvalid)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"code":1002,"message":"Invalid order."}` + "\n",
+		},
+		{
+			name: "written",
+			handler: func(c *gin.Context) {
+				c.Error(errNotFound)
+				c.String(http.StatusAccepted, "accepted")
+			},
+			wantStatus: http.StatusAccepted,
+			wantBody:   "accepted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(gindecode.Middleware(dec))
+			r.GET("/orders/:id", tt.handler)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("unexpected status: got=%d want=%d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Fatalf("unexpected body: got=%s want=%s", got, tt.wantBody)
+			}
+		})
+	}
+}
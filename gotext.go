@@ -0,0 +1,89 @@
+package errdecode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// gotextMessages is the message file format of golang.org/x/text/cmd/gotext.
+type gotextMessages struct {
+	Language string          `json:"language"`
+	Messages []gotextMessage `json:"messages"`
+}
+
+type gotextMessage struct {
+	ID                string              `json:"id"`
+	Message           string              `json:"message"`
+	Translation       string              `json:"translation"`
+	TranslatorComment string              `json:"translatorComment,omitempty"`
+	Placeholders      []gotextPlaceholder `json:"placeholders,omitempty"`
+}
+
+type gotextPlaceholder struct {
+	ID             string `json:"id"`
+	String         string `json:"string"`
+	Type           string `json:"type"`
+	UnderlyingType string `json:"underlyingType"`
+	ArgNum         int    `json:"argNum"`
+	Expr           string `json:"expr"`
+}
+
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ExportGotext writes the messages of the catalog in the message file format
+// of golang.org/x/text/cmd/gotext for the language tag lang, e.g., to
+// locales/fr-FR/messages.gotext.json, so new error messages flow into
+// existing localization workflows.
+//
+// Each distinct message is written once, with its message as ID and an empty
+// translation. "{name}" placeholders are declared as gotext placeholders, in
+// order of appearance, and the codes using a message are noted for
+// translators.
+func (c *Catalog) ExportGotext(w io.Writer, lang string) error {
+	out := gotextMessages{Language: lang, Messages: []gotextMessage{}}
+	index := make(map[string]int)
+	for _, r := range c.Rules {
+		if r.Message == "" {
+			continue
+		}
+		if i, ok := index[r.Message]; ok {
+			out.Messages[i].TranslatorComment += fmt.Sprintf(", %d", r.Code)
+			continue
+		}
+		index[r.Message] = len(out.Messages)
+		out.Messages = append(out.Messages, gotextMessage{
+			ID:                r.Message,
+			Message:           r.Message,
+			TranslatorComment: fmt.Sprintf("Error code %d", r.Code),
+			Placeholders:      gotextPlaceholders(r.Message),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(out)
+}
+
+func gotextPlaceholders(msg string) []gotextPlaceholder {
+	var ps []gotextPlaceholder
+	seen := make(map[string]bool)
+	for _, m := range placeholderPattern.FindAllStringSubmatch(msg, -1) {
+		name := m[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		n := len(ps) + 1
+		ps = append(ps, gotextPlaceholder{
+			ID:             name,
+			String:         fmt.Sprintf("%%[%d]v", n),
+			Type:           "interface{}",
+			UnderlyingType: "interface{}",
+			ArgNum:         n,
+			Expr:           name,
+		})
+	}
+	return ps
+}
@@ -0,0 +1,75 @@
+package errdecode_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestExportGotext(t *testing.T) {
+	c, err := errdecode.ReadCatalog(strings.NewReader(`
+rules:
+  - {code: 1001, message: "Order {id} was not found."}
+  - {code: 1002, message: "Invalid {field}: {field} must be set by {user}."}
+  - {code: 1003, message: "Order {id} was not found."}
+  - {code: 1004}
+`), ".yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var b strings.Builder
+	if err := c.ExportGotext(&b, "fr-FR"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{
+    "language": "fr-FR",
+    "messages": [
+        {
+            "id": "Order {id} was not found.",
+            "message": "Order {id} was not found.",
+            "translation": "",
+            "translatorComment": "Error code 1001, 1003",
+            "placeholders": [
+                {
+                    "id": "id",
+                    "string": "%[1]v",
+                    "type": "interface{}",
+                    "underlyingType": "interface{}",
+                    "argNum": 1,
+                    "expr": "id"
+                }
+            ]
+        },
+        {
+            "id": "Invalid {field}: {field} must be set by {user}.",
+            "message": "Invalid {field}: {field} must be set by {user}.",
+            "translation": "",
+            "translatorComment": "Error code 1002",
+            "placeholders": [
+                {
+                    "id": "field",
+                    "string": "%[1]v",
+                    "type": "interface{}",
+                    "underlyingType": "interface{}",
+                    "argNum": 1,
+                    "expr": "field"
+                },
+                {
+                    "id": "user",
+                    "string": "%[2]v",
+                    "type": "interface{}",
+                    "underlyingType": "interface{}",
+                    "argNum": 2,
+                    "expr": "user"
+                }
+            ]
+        }
+    ]
+}
+`
+	if got := b.String(); got != want {
+		t.Fatalf("unexpected export: got=%s want=%s", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package grpcdecode
+
+import (
+	"context"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/internal/rpcdetails"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Option configures the statuses built from classified errors.
+type Option func(*rpcdetails.Config)
+
+func newConfig(options []Option) *rpcdetails.Config {
+	c := &rpcdetails.Config{}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Domain is used to set the domain of errdetails.ErrorInfo details, e.g.,
+// "orders.acme.com".
+func Domain(domain string) Option {
+	return func(c *rpcdetails.Config) {
+		c.Domain = domain
+	}
+}
+
+// Locale is used to set the BCP 47 locale of errdetails.LocalizedMessage
+// details from the request context, e.g., the locale the message was
+// translated to. It defaults to the locale of the context, see
+// errdecode.WithLocale, then to the default locale of the decoder, see
+// errdecode.Locales, then to "en-US".
+func Locale(fn func(ctx context.Context) string) Option {
+	return func(c *rpcdetails.Config) {
+		c.Locale = fn
+	}
+}
+
+// details returns the error details of a classified error.
+func details(ctx context.Context, d *errdecode.Decoder, ce errdecode.ClassifiedError, rule errdecode.Rule, c *rpcdetails.Config) []protoadapt.MessageV1 {
+	msgs := rpcdetails.Details(ctx, d, ce, rule, c)
+	ds := make([]protoadapt.MessageV1, len(msgs))
+	for i, msg := range msgs {
+		ds[i] = protoadapt.MessageV1Of(msg)
+	}
+	return ds
+}
@@ -0,0 +1,163 @@
+package grpcdecode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/grpcdecode"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+type localeKey struct{}
+
+func TestStatusDetails(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Order {order_id} not found.", Match: func(err error) bool { return errors.Is(err, errNotFound) }},
+	}, errdecode.FieldRules(errdecode.FieldRule{Kind: "required", Code: 2001, Message: "{field} is required."}))
+	options := []grpcdecode.Option{
+		grpcdecode.Domain("orders.example.com"),
+		grpcdecode.Locale(func(ctx context.Context) string { return ctx.Value(localeKey{}).(string) }),
+	}
+	ctx := context.WithValue(context.Background(), localeKey{}, "fr-FR")
+
+	tests := []struct {
+		name          string
+		err           error
+		wantReason    string
+		wantMetadata  map[string]string
+		wantMessage   string
+		wantViolation string
+	}{
+		{
+			name:         "fields",
+			err:          &fieldsError{errNotFound, map[string]interface{}{"order_id": 42}},
+			wantReason:   "1001",
+			wantMetadata: map[string]string{"order_id": "42"},
+			wantMessage:  "Order 42 not found.",
+		},
+		{
+			name:          "field error",
+			err:           errdecode.NewFieldError("email", "required"),
+			wantReason:    "2001",
+			wantMetadata:  map[string]string{"field": "email"},
+			wantMessage:   "email is required.",
+			wantViolation: "email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, _ := grpcdecode.Status(ctx, dec, tt.err, options...)
+
+			var info *errdetails.ErrorInfo
+			var localized *errdetails.LocalizedMessage
+			var badRequest *errdetails.BadRequest
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *errdetails.ErrorInfo:
+					info = d
+				case *errdetails.LocalizedMessage:
+					localized = d
+				case *errdetails.BadRequest:
+					badRequest = d
+				}
+			}
+
+			if info == nil || info.Reason != tt.wantReason || info.Domain != "orders.example.com" {
+				t.Fatalf("unexpected error info: got=%v want reason=%s", info, tt.wantReason)
+			}
+			if len(info.Metadata) != len(tt.wantMetadata) {
+				t.Fatalf("unexpected metadata: got=%v want=%v", info.Metadata, tt.wantMetadata)
+			}
+			for k, v := range tt.wantMetadata {
+				if info.Metadata[k] != v {
+					t.Fatalf("unexpected metadata %s: got=%s want=%s", k, info.Metadata[k], v)
+				}
+			}
+			if localized == nil || localized.Locale != "fr-FR" || localized.Message != tt.wantMessage {
+				t.Fatalf("unexpected localized message: got=%v want=%s", localized, tt.wantMessage)
+			}
+			switch {
+			case tt.wantViolation == "" && badRequest != nil:
+				t.Fatalf("unexpected bad request: got=%v want=nil", badRequest)
+			case tt.wantViolation != "" && (badRequest == nil || len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != tt.wantViolation):
+				t.Fatalf("unexpected bad request: got=%v want=%s", badRequest, tt.wantViolation)
+			}
+		})
+	}
+}
+
+// fieldsError wraps an error with fields.
+type fieldsError struct {
+	err    error
+	fields map[string]interface{}
+}
+
+func (e *fieldsError) Error() string                  { return e.err.Error() }
+func (e *fieldsError) Unwrap() error                  { return e.err }
+func (e *fieldsError) Fields() map[string]interface{} { return e.fields }
+
+func TestStatusRetryInfo(t *testing.T) {
+	errBusy := errors.New("busy")
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Busy.", Errors: []error{errBusy}, Retryable: true, RetryAfter: 30 * time.Second},
+		{Code: 1002, Message: "Not found.", Errors: []error{errNotFound}, RetryAfter: 30 * time.Second},
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{"retryable", errBusy, 30 * time.Second},
+		{"not retryable", errNotFound, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, _ := grpcdecode.Status(context.Background(), dec, tt.err)
+			var got time.Duration
+			for _, d := range st.Details() {
+				if info, ok := d.(*errdetails.RetryInfo); ok {
+					got = info.RetryDelay.AsDuration()
+				}
+			}
+			if got != tt.want {
+				t.Fatalf("unexpected retry delay: got=%s want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusLocale(t *testing.T) {
+	rules := []errdecode.Rule{{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}}}
+
+	tests := []struct {
+		name       string
+		dec        *errdecode.Decoder
+		ctx        context.Context
+		wantLocale string
+	}{
+		{"context locale", errdecode.New(rules, errdecode.Locales("fr", "de")), errdecode.WithLocale(context.Background(), "de"), "de"},
+		{"default locale", errdecode.New(rules, errdecode.Locales("fr", "de")), context.Background(), "fr"},
+		{"no locales", errdecode.New(rules), context.Background(), "en-US"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, _ := grpcdecode.Status(tt.ctx, tt.dec, errNotFound)
+			for _, d := range st.Details() {
+				if localized, ok := d.(*errdetails.LocalizedMessage); ok {
+					if localized.Locale != tt.wantLocale {
+						t.Fatalf("unexpected locale: got=%s want=%s", localized.Locale, tt.wantLocale)
+					}
+					return
+				}
+			}
+			t.Fatalf("missing localized message")
+		})
+	}
+}
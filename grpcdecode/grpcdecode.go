@@ -0,0 +1,97 @@
+// Package grpcdecode translates errors returned by gRPC handlers into gRPC
+// statuses with an errdecode.Decoder:
+//
+//	srv := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(grpcdecode.UnaryServerInterceptor(decoder)),
+//		grpc.ChainStreamInterceptor(grpcdecode.StreamServerInterceptor(decoder)),
+//	)
+//
+// The status code is the one of the rule, see errdecode.Rule.RPCCode. The
+// status message is the translated message, and the code of the rule is sent
+// in the CodeTrailer trailer. Statuses also carry standard error details, see
+// Status.
+package grpcdecode
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/iamrgon/errdecode"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// CodeTrailer is the trailer holding the code of a classified error.
+const CodeTrailer = "errdecode-code"
+
+// UnaryServerInterceptor returns a unary server interceptor translating the
+// errors returned by handlers with d.
+func UnaryServerInterceptor(d *errdecode.Decoder, options ...Option) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			st, code := Status(ctx, d, err, options...)
+			if code != 0 {
+				grpc.SetTrailer(ctx, codeTrailer(code))
+			}
+			return resp, st.Err()
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor translating
+// the errors returned by handlers with d.
+func StreamServerInterceptor(d *errdecode.Decoder, options ...Option) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			st, code := Status(ss.Context(), d, err, options...)
+			if code != 0 {
+				ss.SetTrailer(codeTrailer(code))
+			}
+			return st.Err()
+		}
+		return nil
+	}
+}
+
+// Status translates err with d and returns its gRPC status along with the
+// code of its classification.
+//
+// The status of a classified error carries the following details:
+//
+//   - errdetails.ErrorInfo, with the code as reason, the domain set by Domain
+//     and the fields of the error as metadata, see errdecode.Fielder
+//   - errdetails.LocalizedMessage, with the translated message and the locale
+//     set by Locale
+//   - errdetails.RetryInfo, with the RetryAfter delay of retryable rules
+//   - errdetails.BadRequest, with a field violation for field errors, see
+//     errdecode.ClassifiedFieldError
+//
+// Unclassified errors already carrying a gRPC status, e.g., returned by a
+// downstream client, keep it. Other unclassified errors are reported as
+// codes.Internal with a generic message, so their message never reaches
+// clients.
+func Status(ctx context.Context, d *errdecode.Decoder, err error, options ...Option) (*status.Status, int) {
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) && !errors.As(d.TranslateContext(ctx, err), &ce) {
+		if st, ok := status.FromError(err); ok {
+			return st, 0
+		}
+		return status.New(codes.Internal, "internal error"), 0
+	}
+	rule, _ := d.RuleFor(ce.Code())
+	st := status.New(codes.Code(rule.RPCCode()), ce.Error())
+	if withDetails, err := st.WithDetails(details(ctx, d, ce, rule, newConfig(options))...); err == nil {
+		st = withDetails
+	}
+	return st, ce.Code()
+}
+
+func codeTrailer(code int) metadata.MD {
+	return metadata.Pairs(CodeTrailer, strconv.Itoa(code))
+}
@@ -0,0 +1,116 @@
+package grpcdecode_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/grpcdecode"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errNotFound    = errors.New("order not found")
+	errInvalid     = errors.New("invalid order")
+	errUnspecified = errors.New("unspecified")
+	errUnhandled   = errors.New("connection reset by peer")
+)
+
+func newDecoder() *errdecode.Decoder {
+	return errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}, HTTPStatus: http.StatusNotFound},
+		{Code: 1002, Message: "Invalid order.", Errors: []error{errInvalid}, HTTPStatus: http.StatusBadRequest, GRPCCode: int(codes.FailedPrecondition)},
+		{Code: 1003, Message: "Unspecified.", Errors: []error{errUnspecified}},
+	})
+}
+
+func TestStatus(t *testing.T) {
+	dec := newDecoder()
+
+	tests := []struct {
+		name        string
+		err         error
+		wantGRPC    codes.Code
+		wantMessage string
+		wantCode    int
+	}{
+		{"from HTTP status", errNotFound, codes.NotFound, "Order not found.", 1001},
+		{"gRPC code", errInvalid, codes.FailedPrecondition, "Invalid order.", 1002},
+		{"unspecified", errUnspecified, codes.Unknown, "Unspecified.", 1003},
+		{"unclassified", errUnhandled, codes.Internal, "internal error", 0},
+		{"unclassified status", status.Error(codes.Unavailable, "downstream"), codes.Unavailable, "downstream", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, code := grpcdecode.Status(context.Background(), dec, tt.err)
+			if st.Code() != tt.wantGRPC || st.Message() != tt.wantMessage || code != tt.wantCode {
+				t.Fatalf("unexpected status: got=%s %q %d want=%s %q %d", st.Code(), st.Message(), code, tt.wantGRPC, tt.wantMessage, tt.wantCode)
+			}
+		})
+	}
+}
+
+// transportStream records the trailers set by handlers.
+type transportStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := grpcdecode.UnaryServerInterceptor(newDecoder())
+	stream := new(transportStream)
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errNotFound
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("unexpected code: got=%s want=%s", status.Code(err), codes.NotFound)
+	}
+	if got := stream.trailer.Get(grpcdecode.CodeTrailer); len(got) != 1 || got[0] != "1001" {
+		t.Fatalf("unexpected trailer: got=%v want=[1001]", got)
+	}
+
+	resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	if resp != "ok" || err != nil {
+		t.Fatalf("unexpected response: got=%v %v want=ok <nil>", resp, err)
+	}
+}
+
+// serverStream records the trailers set by the interceptor.
+type serverStream struct {
+	grpc.ServerStream
+	trailer metadata.MD
+}
+
+func (s *serverStream) Context() context.Context { return context.Background() }
+
+func (s *serverStream) SetTrailer(md metadata.MD) { s.trailer = metadata.Join(s.trailer, md) }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := grpcdecode.StreamServerInterceptor(newDecoder())
+	ss := new(serverStream)
+
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		return errInvalid
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("unexpected code: got=%s want=%s", status.Code(err), codes.FailedPrecondition)
+	}
+	if got := ss.trailer.Get(grpcdecode.CodeTrailer); len(got) != 1 || got[0] != "1002" {
+		t.Fatalf("unexpected trailer: got=%v want=[1002]", got)
+	}
+}
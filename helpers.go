@@ -0,0 +1,51 @@
+package errdecode
+
+import (
+	"errors"
+	"strconv"
+)
+
+// CodeTarget is an errors.Is target matching classified errors by code:
+//
+//	if errors.Is(err, errdecode.CodeTarget(1001)) {
+//		// ...
+//	}
+type CodeTarget int
+
+// Error satisfies the error interface.
+func (t CodeTarget) Error() string { return "errdecode: code " + strconv.Itoa(int(t)) }
+
+// Code returns the classification of the first classified error in the wrap
+// chain of err, or zero if err is unclassified.
+func Code(err error) int {
+	var ce ClassifiedError
+	if !errors.As(err, &ce) {
+		return 0
+	}
+	return ce.Code()
+}
+
+// MessageOf returns the translated message of the first classified error in
+// the wrap chain of err, or an empty string if err is unclassified.
+func MessageOf(err error) string {
+	var ce ClassifiedError
+	if !errors.As(err, &ce) {
+		return ""
+	}
+	return ce.Error()
+}
+
+// IsCode reports whether the first classified error in the wrap chain of err
+// has the given code.
+func IsCode(err error, code int) bool {
+	return code != 0 && Code(err) == code
+}
+
+// Classify returns a classified error with no underlying cause, e.g., for a
+// business rule rejection that does not originate from another error.
+//
+// Since no rule is involved, the message is used as-is and the error is
+// neither retryable nor has a severity.
+func Classify(code int, message string) ClassifiedError {
+	return &matchedError{code: code, msg: message, key: message}
+}
@@ -0,0 +1,91 @@
+package errdecode_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestHelpers(t *testing.T) {
+	dec := newDecoder()
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantMsg  string
+	}{
+		{"nil", nil, 0, ""},
+		{"unclassified", dec.Translate(errors.New("unmatched")), 0, ""},
+		{"classified", dec.Translate(errClient1), codeClientError, "error.client"},
+		{"wrapped classified", fmt.Errorf("handler: %w", dec.Translate(errClient2)), codeClientError, "error.client"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := errdecode.Code(tt.err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			if msg := errdecode.MessageOf(tt.err); msg != tt.wantMsg {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, tt.wantMsg)
+			}
+			if isCode := errdecode.IsCode(tt.err, codeClientError); isCode != (tt.wantCode == codeClientError) {
+				t.Fatalf("unexpected IsCode result: got=%t", isCode)
+			}
+		})
+	}
+
+	if errdecode.IsCode(errors.New("unmatched"), 0) {
+		t.Fatalf("expected unclassified errors to never match a code")
+	}
+}
+
+func TestCodeTarget(t *testing.T) {
+	dec := newDecoder()
+	customErr := newCustomError("custom error")
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"matching code", dec.Translate(errClient1), errdecode.CodeTarget(codeClientError), true},
+		{"wrapped matching code", fmt.Errorf("handler: %w", dec.Translate(errClient1)), errdecode.CodeTarget(codeClientError), true},
+		{"other code", dec.Translate(errClient1), errdecode.CodeTarget(codeCustomError), false},
+		{"unclassified", dec.Translate(errors.New("unmatched")), errdecode.CodeTarget(codeClientError), false},
+		{"underlying value is still matched", dec.Translate(errClient1), errClient1, true},
+		{"underlying pointer is still matched", dec.Translate(customErr), customErr, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Fatalf("unexpected result: got=%t want=%t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifiedErrorAs(t *testing.T) {
+	err := newDecoder().Translate(newCustomError("custom error"))
+	var ce *CustomError
+	if !errors.As(err, &ce) || string(*ce) != "custom error" {
+		t.Fatalf("expected underlying error type to be reachable, got: %v", ce)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	err := fmt.Errorf("checkout: %w", errdecode.Classify(codeCustomError, "Orders above the limit need approval."))
+
+	if code := errdecode.Code(err); code != codeCustomError {
+		t.Fatalf("unexpected code: got=%d want=%d", code, codeCustomError)
+	}
+	if msg := errdecode.MessageOf(err); msg != "Orders above the limit need approval." {
+		t.Fatalf("unexpected message: got='%s'", msg)
+	}
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) || ce.Unwrap() != nil || ce.MessageKey() != ce.Error() {
+		t.Fatalf("expected a classified error without cause")
+	}
+}
@@ -0,0 +1,109 @@
+package httpdecode
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// HTMLPages is used to render errors as HTML pages for requests accepting
+// text/html, e.g., in server-rendered apps, see WriteError.
+//
+// Pages are rendered with the template of t named after the code of the
+// error, e.g., "1001", or else after the HTTP status, e.g., "404", or else
+// named "error". The data passed to templates is a Page. If t is nil or has
+// no matching template, DefaultPage is used.
+func HTMLPages(t *template.Template) Option {
+	return func(m *Middleware) {
+		m.html = true
+		m.pages = t
+	}
+}
+
+// Page is the data passed to the templates of HTML pages.
+type Page struct {
+	// Code is the classification of the error, zero if unclassified.
+	Code int
+
+	// Message is the translated message.
+	Message string
+
+	// Status is the HTTP status code.
+	Status int
+
+	// StatusText is the text of the HTTP status, e.g., "Not Found".
+	StatusText string
+
+	// Fields are the fields of the error, see errdecode.Fielder.
+	Fields map[string]interface{}
+
+	// DocsURL links to documentation for errors of this class, if any.
+	DocsURL string
+
+	// Retryable reports whether the failed request may succeed if retried.
+	Retryable bool
+}
+
+// DefaultPage is the template of HTML pages used if none is provided.
+var DefaultPage = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Status}} {{.StatusText}}</title>
+</head>
+<body>
+<main>
+<h1>{{.StatusText}}</h1>
+<p>{{.Message}}</p>
+{{- if .Retryable}}
+<p>Please try again later.</p>
+{{- end}}
+{{- if .DocsURL}}
+<p><a href="{{.DocsURL}}">Learn more</a></p>
+{{- end}}
+{{- if .Code}}
+<p><small>Error code {{.Code}}</small></p>
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+// page returns the template rendering errors with the given code and status.
+func (m *Middleware) page(code, status int) *template.Template {
+	if m.pages != nil {
+		for _, name := range []string{strconv.Itoa(code), strconv.Itoa(status), "error"} {
+			if t := m.pages.Lookup(name); t != nil {
+				return t
+			}
+		}
+	}
+	return DefaultPage
+}
+
+// htmlResponse renders the HTML page of a classified error. If rendering
+// fails, the error is described as plain text.
+func (m *Middleware) htmlResponse(ce errdecode.ClassifiedError, rule errdecode.Rule, status int) Response {
+	page := Page{
+		Code:       ce.Code(),
+		Message:    ce.Error(),
+		Status:     status,
+		StatusText: http.StatusText(status),
+		Fields:     ce.Fields(),
+		DocsURL:    rule.DocsURL,
+		Retryable:  ce.Retryable(),
+	}
+	var buf bytes.Buffer
+	if err := m.page(page.Code, status).Execute(&buf, page); err != nil {
+		return textResponse(status, ce.Error())
+	}
+	return Response{
+		Status: status,
+		Header: http.Header{"Content-Type": {"text/html; charset=utf-8"}, "X-Content-Type-Options": {"nosniff"}},
+		Body:   buf.Bytes(),
+	}
+}
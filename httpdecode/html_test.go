@@ -0,0 +1,59 @@
+package httpdecode_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode/httpdecode"
+)
+
+func TestHTMLPages(t *testing.T) {
+	dec := newDecoder()
+	pages := template.Must(template.New("1001").Parse(`<p>{{.Message}} ({{.Code}})</p>`))
+	template.Must(pages.New("500").Parse(`<p>Oops: {{.Message}}</p>`))
+
+	tests := []struct {
+		name     string
+		pages    *template.Template
+		err      error
+		accept   string
+		wantType string
+		wantBody string
+	}{
+		{"by code", pages, errNotFound, "text/html", "text/html; charset=utf-8", "<p>Order not found. (1001)</p>"},
+		{"by status", pages, errConflict, "text/html", "text/html; charset=utf-8", "<p>Oops: Order conflict.</p>"},
+		{"default page", nil, errNotFound, "text/html,application/xhtml+xml", "text/html; charset=utf-8", "<h1>Not Found</h1>\n<p>Order not found.</p>"},
+		{"fallback to default page", template.New("empty"), errConflict, "text/html", "text/html; charset=utf-8", "<small>Error code 1002</small>"},
+		{"json preferred", pages, errNotFound, "application/json, text/html;q=0.5", "application/json; charset=utf-8", `{"code":1001`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+			r.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			httpdecode.New(dec, httpdecode.HTMLPages(tt.pages)).WriteError(rec, r, tt.err)
+
+			if ct := rec.Header().Get("Content-Type"); ct != tt.wantType {
+				t.Fatalf("unexpected content type: got=%s want=%s", ct, tt.wantType)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Fatalf("unexpected body: got=%s want=%s", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHTMLPagesDisabled(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+	r.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+	httpdecode.New(newDecoder()).WriteError(rec, r, errNotFound)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Fatalf("unexpected content type: got=%s want=application/json", ct)
+	}
+}
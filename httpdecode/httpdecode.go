@@ -0,0 +1,205 @@
+// Package httpdecode writes errors classified by an errdecode.Decoder as HTTP
+// responses.
+//
+// Handlers return errors instead of writing error responses themselves:
+//
+//	mw := httpdecode.New(decoder)
+//	mux.Handle("/orders/", mw.Handler(func(w http.ResponseWriter, r *http.Request) error {
+//		order, err := store.Find(r.Context(), r.URL.Path)
+//		if err != nil {
+//			return err
+//		}
+//		return json.NewEncoder(w).Encode(order)
+//	}))
+//
+// Errors are translated with the request context, and written as JSON
+// errdecode.ErrorPayload values, with the HTTP status of their rule, so
+// clients read them with errdecode.ParseResponse.
+package httpdecode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// HandlerFunc is an HTTP handler returning an error. It must not write to
+// the response when it returns an error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Middleware translates the errors returned by handlers into HTTP responses.
+type Middleware struct {
+	decoder       *errdecode.Decoder
+	defaultStatus int
+	onError       func(r *http.Request, err error)
+	problems      bool
+	html          bool
+	pages         *template.Template
+}
+
+// Option configures a Middleware.
+type Option func(*Middleware)
+
+// DefaultStatus is used to set the HTTP status of classified errors whose
+// rule has none. It defaults to 500 Internal Server Error.
+func DefaultStatus(status int) Option {
+	return func(m *Middleware) {
+		m.defaultStatus = status
+	}
+}
+
+// OnError is used to be notified of every error returned by a handler, along
+// with the request, e.g., to log it. The error is passed before translation.
+func OnError(fn func(r *http.Request, err error)) Option {
+	return func(m *Middleware) {
+		m.onError = fn
+	}
+}
+
+// New returns a middleware translating errors with d.
+func New(d *errdecode.Decoder, options ...Option) *Middleware {
+	m := &Middleware{decoder: d, defaultStatus: http.StatusInternalServerError}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// Handler adapts h to an http.Handler writing the errors it returns.
+func (m *Middleware) Handler(h HandlerFunc) http.Handler {
+	return m.HandlerFunc(h)
+}
+
+// HandlerFunc adapts h to an http.HandlerFunc writing the errors it returns.
+func (m *Middleware) HandlerFunc(h HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h(w, r); err != nil {
+			m.WriteError(w, r, err)
+		}
+	}
+}
+
+// Response is the HTTP response describing an error.
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Response translates err with ctx and returns the response the middleware
+// writes for it, e.g., to adapt the middleware to other HTTP frameworks.
+//
+// Unclassified errors are described as a 500 Internal Server Error with code
+// 0, so their message never reaches clients.
+func (m *Middleware) Response(ctx context.Context, err error) Response {
+	ce, rule, status := m.classify(ctx, err)
+	return m.respond(ctx, ce, rule, status, formatDefault)
+}
+
+// Respond returns the response describing an error already classified, e.g.,
+// by Classify, with the given HTTP status. Having no request context, the
+// payload has no correlation ID, see RespondContext.
+func (m *Middleware) Respond(ce errdecode.ClassifiedError, status int) Response {
+	return m.RespondContext(context.Background(), ce, status)
+}
+
+// RespondContext is like Respond, but the payload is built with ctx, e.g.,
+// for its correlation ID.
+func (m *Middleware) RespondContext(ctx context.Context, ce errdecode.ClassifiedError, status int) Response {
+	rule, _ := m.decoder.RuleFor(ce.Code())
+	return m.respond(ctx, ce, rule, status, formatDefault)
+}
+
+func (m *Middleware) respond(ctx context.Context, ce errdecode.ClassifiedError, rule errdecode.Rule, status int, f format) Response {
+	if f == formatDefault {
+		f = formatJSON
+		if m.problems {
+			f = formatProblem
+		}
+	}
+
+	var resp Response
+	switch f {
+	case formatProblem:
+		resp = jsonResponse("application/problem+json", status, NewProblem(ce, rule, status))
+	case formatText:
+		resp = textResponse(status, ce.Error())
+	case formatHTML:
+		resp = m.htmlResponse(ce, rule, status)
+	default:
+		resp = jsonResponse("application/json; charset=utf-8", status, m.decoder.Payload(ctx, ce))
+	}
+	if v := RetryAfter(rule); v != "" {
+		resp.Header.Set("Retry-After", v)
+	}
+	return resp
+}
+
+// RetryAfter returns the Retry-After header value for errors of a rule, i.e.,
+// its RetryAfter delay in seconds rounded up, or an empty string if the rule
+// is not retryable or has no delay.
+func RetryAfter(rule errdecode.Rule) string {
+	if !rule.Retryable || rule.RetryAfter <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(int64((rule.RetryAfter+time.Second-1)/time.Second), 10)
+}
+
+// Write writes the response to w.
+func (resp Response) Write(w http.ResponseWriter) {
+	for k, vs := range resp.Header {
+		w.Header()[k] = vs
+	}
+	w.WriteHeader(resp.Status)
+	w.Write(resp.Body)
+}
+
+// Classify translates err with ctx and returns its classified form and HTTP
+// status, as written by the middleware.
+func (m *Middleware) Classify(ctx context.Context, err error) (errdecode.ClassifiedError, int) {
+	ce, _, status := m.classify(ctx, err)
+	return ce, status
+}
+
+// classify returns the classified form of err, its rule and its HTTP status.
+// Errors already classified, e.g., by Decoder.NewError, are not translated
+// again.
+func (m *Middleware) classify(ctx context.Context, err error) (errdecode.ClassifiedError, errdecode.Rule, int) {
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) && !errors.As(m.decoder.TranslateContext(ctx, err), &ce) {
+		status := http.StatusInternalServerError
+		return errdecode.Classify(0, http.StatusText(status)), errdecode.Rule{}, status
+	}
+	rule, _ := m.decoder.RuleFor(ce.Code())
+	if rule.HTTPStatus != 0 {
+		return ce, rule, rule.HTTPStatus
+	}
+	return ce, rule, m.defaultStatus
+}
+
+func jsonResponse(contentType string, status int, v interface{}) Response {
+	body, err := json.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return textResponse(status, http.StatusText(status))
+	}
+	return Response{
+		Status: status,
+		Header: http.Header{"Content-Type": {contentType}, "X-Content-Type-Options": {"nosniff"}},
+		Body:   append(body, '\n'),
+	}
+}
+
+func textResponse(status int, msg string) Response {
+	return Response{
+		Status: status,
+		Header: http.Header{"Content-Type": {"text/plain; charset=utf-8"}, "X-Content-Type-Options": {"nosniff"}},
+		Body:   []byte(msg + "\n"),
+	}
+}
@@ -0,0 +1,129 @@
+package httpdecode_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/httpdecode"
+)
+
+var (
+	errNotFound  = errors.New("order not found")
+	errConflict  = errors.New("order conflict")
+	errUnhandled = errors.New("connection reset by peer")
+)
+
+func newDecoder() *errdecode.Decoder {
+	return errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}, HTTPStatus: http.StatusNotFound},
+		{Code: 1002, Message: "Order conflict.", Errors: []error{errConflict}},
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	dec := newDecoder()
+
+	tests := []struct {
+		name       string
+		err        error
+		options    []httpdecode.Option
+		wantStatus int
+		wantBody   string
+	}{
+		{"no error", nil, nil, http.StatusOK, "ok"},
+		{"rule status", errNotFound, nil, http.StatusNotFound, `{"code":1001,"message":"Order not found."}` + "\n"},
+		{"default status", errConflict, nil, http.StatusInternalServerError, `{"code":1002,"message":"Order conflict."}` + "\n"},
+		{"custom default status", errConflict, []httpdecode.Option{httpdecode.DefaultStatus(http.StatusBadRequest)}, http.StatusBadRequest, `{"code":1002,"message":"Order conflict."}` + "\n"},
+		{"pre-classified", fmt.Errorf("find: %w", dec.NewError(1001)), nil, http.StatusNotFound, `{"code":1001,"message":"Order not found."}` + "\n"},
+		{"unclassified", errUnhandled, nil, http.StatusInternalServerError, `{"code":0,"message":"Internal Server Error"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported error
+			options := append([]httpdecode.Option{httpdecode.OnError(func(_ *http.Request, err error) { reported = err })}, tt.options...)
+			h := httpdecode.New(dec, options...).Handler(func(w http.ResponseWriter, r *http.Request) error {
+				if tt.err != nil {
+					return tt.err
+				}
+				_, err := w.Write([]byte("ok"))
+				return err
+			})
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("unexpected status: got=%d want=%d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Fatalf("unexpected body: got=%s want=%s", got, tt.wantBody)
+			}
+			if reported != tt.err {
+				t.Fatalf("unexpected reported error: got=%v want=%v", reported, tt.err)
+			}
+			if tt.err != nil && rec.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+				t.Fatalf("unexpected content type: got=%s", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestMiddlewarePayload(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}, HTTPStatus: http.StatusNotFound, Severity: errdecode.SeverityInfo, DocsURL: "https://example.com/errors/1001"},
+	})
+	h := httpdecode.New(dec).Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errNotFound
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+	req = req.WithContext(errdecode.WithCorrelationID(req.Context(), "req-1"))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	want := `{"code":1001,"message":"Order not found.","severity":"info","docs_url":"https://example.com/errors/1001","correlation_id":"req-1"}` + "\n"
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("unexpected body: got=%s want=%s", got, want)
+	}
+
+	err := errdecode.ParseResponse(rec.Result())
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) || ce.Code() != 1001 || ce.Severity() != errdecode.SeverityInfo {
+		t.Fatalf("unexpected parsed error: got=%v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	errBusy := errors.New("busy")
+	errFlaky := errors.New("flaky")
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Busy.", Errors: []error{errBusy}, HTTPStatus: http.StatusServiceUnavailable, Retryable: true, RetryAfter: 1500 * time.Millisecond},
+		{Code: 1002, Message: "Flaky.", Errors: []error{errFlaky}, Retryable: true},
+		{Code: 1003, Message: "Conflict.", Errors: []error{errConflict}, RetryAfter: time.Minute},
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"hint", errBusy, "2"},
+		{"no hint", errFlaky, ""},
+		{"not retryable", errConflict, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httpdecode.New(dec).Response(context.Background(), tt.err)
+			if got := resp.Header.Get("Retry-After"); got != tt.want {
+				t.Fatalf("unexpected Retry-After: got=%q want=%q", got, tt.want)
+			}
+		})
+	}
+}
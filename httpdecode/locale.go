@@ -0,0 +1,102 @@
+package httpdecode
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// NegotiateLocale returns a middleware that negotiates the locale of each
+// request from its Accept-Language header against the locales of d, see
+// errdecode.Locales, and attaches it to the request context with
+// errdecode.WithLocale, so errors translated with the request context use
+// it.
+//
+// Requests accepting none of the locales of d use its default locale.
+// Decoders without locales leave requests untouched.
+func NegotiateLocale(d *errdecode.Decoder) func(http.Handler) http.Handler {
+	available := d.Locales()
+	return func(next http.Handler) http.Handler {
+		if len(available) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale, ok := Negotiate(r.Header.Get("Accept-Language"), available)
+			if !ok {
+				locale = available[0]
+			}
+			next.ServeHTTP(w, r.WithContext(errdecode.WithLocale(r.Context(), locale)))
+		})
+	}
+}
+
+// Negotiate returns the available locale best matching an Accept-Language
+// header. It returns false if no locale is acceptable.
+//
+// Language ranges are tried by decreasing quality. A range matches a locale
+// with the same tag, ignoring case, then a locale of the same base language,
+// e.g., "fr-CA" matches "fr" or "fr-FR". The "*" range matches the first
+// available locale.
+func Negotiate(acceptLanguage string, available []string) (string, bool) {
+	for _, tag := range parseQualityList(acceptLanguage) {
+		if tag == "*" && len(available) > 0 {
+			return available[0], true
+		}
+		for _, locale := range available {
+			if strings.EqualFold(tag, locale) {
+				return locale, true
+			}
+		}
+		base := baseLanguage(tag)
+		for _, locale := range available {
+			if strings.EqualFold(base, baseLanguage(locale)) {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseQualityList returns the values of a header weighted by quality, e.g.,
+// Accept or Accept-Language, by decreasing quality. Values with a zero
+// quality are dropped.
+func parseQualityList(header string) []string {
+	type weighted struct {
+		value string
+		q     float64
+	}
+	var values []weighted
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		value := strings.TrimSpace(params[0])
+		if value == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			values = append(values, weighted{value, q})
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool { return values[i].q > values[j].q })
+
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = v.value
+	}
+	return result
+}
+
+func baseLanguage(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return base
+}
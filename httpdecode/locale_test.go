@@ -0,0 +1,65 @@
+package httpdecode_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/httpdecode"
+)
+
+func TestNegotiate(t *testing.T) {
+	available := []string{"en-US", "fr-FR", "de"}
+
+	tests := []struct {
+		header string
+		want   string
+		wantOK bool
+	}{
+		{"fr-FR", "fr-FR", true},
+		{"FR-fr", "fr-FR", true},
+		{"fr-CA", "fr-FR", true},
+		{"de-AT, en;q=0.5", "de", true},
+		{"en;q=0.5, fr;q=0.8", "fr-FR", true},
+		{"es, fr;q=0", "", false},
+		{"es, *;q=0.1", "en-US", true},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, ok := httpdecode.Negotiate(tt.header, available)
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("unexpected locale: got=%s %t want=%s %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	dec := errdecode.New(nil, errdecode.Locales("en-US", "fr-FR"))
+
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"fr-CA,fr;q=0.9", "fr-FR"},
+		{"es", "en-US"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			var got string
+			h := httpdecode.NegotiateLocale(dec)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = errdecode.LocaleFromContext(r.Context())
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept-Language", tt.header)
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			if got != tt.want {
+				t.Fatalf("unexpected locale: got=%s want=%s", got, tt.want)
+			}
+		})
+	}
+}
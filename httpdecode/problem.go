@@ -0,0 +1,94 @@
+package httpdecode
+
+import (
+	"encoding/json"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// ProblemDetails is used to write errors as RFC 7807 problem details, with
+// the application/problem+json media type, instead of errdecode.ErrorPayload.
+// See Problem.
+func ProblemDetails() Option {
+	return func(m *Middleware) {
+		m.problems = true
+	}
+}
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	// Type is a URI identifying the problem type, "about:blank" if none.
+	Type string
+
+	// Title is a short summary of the problem type.
+	Title string
+
+	// Status is the HTTP status code.
+	Status int
+
+	// Detail is an explanation specific to this occurrence of the problem.
+	Detail string
+
+	// Instance is a URI identifying this occurrence of the problem.
+	Instance string
+
+	// Extensions are additional members. Members named after a standard
+	// member are ignored.
+	Extensions map[string]interface{}
+}
+
+// NewProblem returns the problem details of a classified error and its rule:
+//
+//	{
+//		"type": "https://docs.example.com/errors/1001",
+//		"title": "The provided token is not valid.",
+//		"status": 401,
+//		"code": 1001,
+//		"client_id": "..."
+//	}
+//
+// The type is the DocsURL of the rule and the title the translated message.
+// The code is added as an extension member, along with the fields of the
+// error, see errdecode.Fielder.
+func NewProblem(ce errdecode.ClassifiedError, rule errdecode.Rule, status int) Problem {
+	p := Problem{
+		Type:       rule.DocsURL,
+		Title:      ce.Error(),
+		Status:     status,
+		Extensions: map[string]interface{}{"code": ce.Code()},
+	}
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+	for k, v := range ce.Fields() {
+		if _, exists := p.Extensions[k]; !exists {
+			p.Extensions[k] = v
+		}
+	}
+	return p
+}
+
+// MarshalJSON satisfies the json.Marshaler interface. Extension members are
+// inlined next to the standard members.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		switch k {
+		case "type", "title", "status", "detail", "instance":
+		default:
+			m[k] = v
+		}
+	}
+	m["type"] = p.Type
+	m["title"] = p.Title
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,103 @@
+package httpdecode_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/httpdecode"
+)
+
+type fieldError struct{ id string }
+
+func (e fieldError) Error() string { return "order " + e.id + " not found" }
+
+func (e fieldError) Fields() map[string]interface{} {
+	return map[string]interface{}{"order_id": e.id, "status": "ignored"}
+}
+
+func TestProblemDetails(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{
+			Code:       1001,
+			Message:    "Order {order_id} not found.",
+			Match:      func(err error) bool { _, ok := err.(fieldError); return ok },
+			HTTPStatus: http.StatusNotFound,
+			DocsURL:    "https://docs.example.com/errors/1001",
+		},
+		{Code: 1002, Message: "Order conflict.", Errors: []error{errConflict}},
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		want       map[string]interface{}
+	}{
+		{
+			name:       "documented",
+			err:        fieldError{"42"},
+			wantStatus: http.StatusNotFound,
+			want: map[string]interface{}{
+				"type":     "https://docs.example.com/errors/1001",
+				"title":    "Order 42 not found.",
+				"status":   float64(http.StatusNotFound),
+				"code":     float64(1001),
+				"order_id": "42",
+			},
+		},
+		{
+			name:       "undocumented",
+			err:        errConflict,
+			wantStatus: http.StatusInternalServerError,
+			want: map[string]interface{}{
+				"type":   "about:blank",
+				"title":  "Order conflict.",
+				"status": float64(http.StatusInternalServerError),
+				"code":   float64(1002),
+			},
+		},
+		{
+			name:       "unclassified",
+			err:        errUnhandled,
+			wantStatus: http.StatusInternalServerError,
+			want: map[string]interface{}{
+				"type":   "about:blank",
+				"title":  "Internal Server Error",
+				"status": float64(http.StatusInternalServerError),
+				"code":   float64(0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := httpdecode.New(dec, httpdecode.ProblemDetails()).Handler(func(w http.ResponseWriter, r *http.Request) error {
+				return tt.err
+			})
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("unexpected status: got=%d want=%d", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Fatalf("unexpected content type: got=%s want=application/problem+json", ct)
+			}
+			var got map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("unexpected problem: got=%v want=%v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Fatalf("unexpected %s: got=%v want=%v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
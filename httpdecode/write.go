@@ -0,0 +1,64 @@
+package httpdecode
+
+import (
+	"net/http"
+	"strings"
+)
+
+// format is the representation of an error response.
+type format int
+
+const (
+	formatDefault format = iota // JSON, or problem details with ProblemDetails
+	formatJSON
+	formatProblem
+	formatText
+	formatHTML
+)
+
+// mediaTypes maps the media ranges of the Accept header to formats.
+var mediaTypes = map[string]format{
+	"application/problem+json": formatProblem,
+	"application/json":         formatJSON,
+	"text/plain":               formatText,
+	"text/html":                formatHTML,
+	"text/*":                   formatText,
+	"application/*":            formatDefault,
+	"*/*":                      formatDefault,
+}
+
+// WriteError translates err with the request context and writes the
+// response, in the representation negotiated from the Accept header of r:
+// JSON, problem details, plain text or, with the HTMLPages option, HTML.
+// Requests accepting any of them, or none, get the default representation,
+// i.e., JSON, or problem details with the ProblemDetails option.
+//
+// It is the single integration point for plain net/http handlers:
+//
+//	func (s *server) getOrder(w http.ResponseWriter, r *http.Request) {
+//		order, err := s.store.Find(r.Context(), r.PathValue("id"))
+//		if err != nil {
+//			s.errors.WriteError(w, r, err)
+//			return
+//		}
+//		json.NewEncoder(w).Encode(order)
+//	}
+func (m *Middleware) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	if m.onError != nil {
+		m.onError(r, err)
+	}
+	ce, rule, status := m.classify(r.Context(), err)
+	resp := m.respond(r.Context(), ce, rule, status, m.negotiate(r.Header.Get("Accept")))
+	resp.Header.Add("Vary", "Accept")
+	resp.Write(w)
+}
+
+// negotiate returns the format best matching an Accept header.
+func (m *Middleware) negotiate(accept string) format {
+	for _, mediaRange := range parseQualityList(accept) {
+		if f, ok := mediaTypes[strings.ToLower(mediaRange)]; ok && (f != formatHTML || m.html) {
+			return f
+		}
+	}
+	return formatDefault
+}
@@ -0,0 +1,52 @@
+package httpdecode_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iamrgon/errdecode/httpdecode"
+)
+
+func TestWriteError(t *testing.T) {
+	dec := newDecoder()
+
+	tests := []struct {
+		name            string
+		accept          string
+		options         []httpdecode.Option
+		wantContentType string
+		wantBody        string
+	}{
+		{"no accept", "", nil, "application/json; charset=utf-8", `{"code":1001,"message":"Order not found."}` + "\n"},
+		{"any", "*/*", []httpdecode.Option{httpdecode.ProblemDetails()}, "application/problem+json", ""},
+		{"json", "application/json", []httpdecode.Option{httpdecode.ProblemDetails()}, "application/json; charset=utf-8", `{"code":1001,"message":"Order not found."}` + "\n"},
+		{"problem", "application/problem+json, application/json;q=0.9", nil, "application/problem+json", ""},
+		{"text", "text/html, text/plain;q=0.8, application/json;q=0.5", nil, "text/plain; charset=utf-8", "Order not found.\n"},
+		{"unsupported", "image/png", nil, "application/json; charset=utf-8", `{"code":1001,"message":"Order not found."}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			httpdecode.New(dec, tt.options...).WriteError(rec, r, errNotFound)
+
+			if rec.Code != http.StatusNotFound {
+				t.Fatalf("unexpected status: got=%d want=%d", rec.Code, http.StatusNotFound)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != tt.wantContentType {
+				t.Fatalf("unexpected content type: got=%s want=%s", ct, tt.wantContentType)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Fatalf("unexpected body: got=%s want=%s", rec.Body.String(), tt.wantBody)
+			}
+			if rec.Header().Get("Vary") != "Accept" {
+				t.Fatalf("unexpected Vary: got=%s want=Accept", rec.Header().Get("Vary"))
+			}
+		})
+	}
+}
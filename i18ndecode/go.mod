@@ -0,0 +1,14 @@
+module github.com/iamrgon/errdecode/i18ndecode
+
+go 1.21
+
+require (
+	github.com/iamrgon/errdecode v0.1.0
+	github.com/nicksnyder/go-i18n/v2 v2.4.1
+	golang.org/x/text v0.19.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
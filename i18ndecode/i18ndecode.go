@@ -0,0 +1,55 @@
+// Package i18ndecode translates the messages of an errdecode.Decoder with
+// github.com/nicksnyder/go-i18n, using the message of rules as message IDs:
+//
+//	bundle := i18n.NewBundle(language.English)
+//	bundle.RegisterUnmarshalFunc("toml", toml.Unmarshal)
+//	bundle.MustLoadMessageFile("active.fr.toml")
+//
+//	decoder := errdecode.New(rules, i18ndecode.Bundle(bundle))
+//	err = decoder.TranslateContext(errdecode.WithLocale(ctx, "fr-FR"), err)
+//
+// Message IDs missing from the bundle are used as messages. Translations are
+// not executed with template data: fields of errors are expanded by the
+// decoder from "{name}" placeholders, see errdecode.Fielder.
+package i18ndecode
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// Localizer is used to translate messages with l, whatever the locale of
+// requests.
+func Localizer(l *i18n.Localizer) errdecode.Option {
+	return errdecode.Message(func(msg string) string {
+		return localize(l, msg)
+	})
+}
+
+// Bundle is used to translate messages with b, to the locale attached to the
+// context passed to TranslateContext, see errdecode.WithLocale. Requests
+// without a locale, or with a locale missing from b, use the default
+// language of b.
+func Bundle(b *i18n.Bundle) errdecode.Option {
+	var localizers sync.Map // locale → *i18n.Localizer
+
+	return errdecode.MessageContext(func(ctx context.Context, msg string) string {
+		locale, _ := errdecode.LocaleFromContext(ctx)
+		l, ok := localizers.Load(locale)
+		if !ok {
+			l, _ = localizers.LoadOrStore(locale, i18n.NewLocalizer(b, locale))
+		}
+		return localize(l.(*i18n.Localizer), msg)
+	})
+}
+
+func localize(l *i18n.Localizer, msg string) string {
+	translated, err := l.Localize(&i18n.LocalizeConfig{MessageID: msg})
+	if err != nil {
+		return msg
+	}
+	return translated
+}
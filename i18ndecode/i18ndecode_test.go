@@ -0,0 +1,73 @@
+package i18ndecode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/i18ndecode"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+var (
+	errNotFound = errors.New("order not found")
+	errDenied   = errors.New("access denied")
+)
+
+type orderError struct{ id int }
+
+func (e *orderError) Error() string                  { return "order not found" }
+func (e *orderError) Fields() map[string]interface{} { return map[string]interface{}{"id": e.id} }
+
+var rules = []errdecode.Rule{
+	{Code: 1001, Message: "error.not_found", Match: func(err error) bool {
+		var oe *orderError
+		return errors.Is(err, errNotFound) || errors.As(err, &oe)
+	}},
+	{Code: 1002, Message: "Access denied.", Errors: []error{errDenied}},
+}
+
+func newBundle(t *testing.T) *i18n.Bundle {
+	t.Helper()
+	b := i18n.NewBundle(language.English)
+	b.MustAddMessages(language.English, &i18n.Message{ID: "error.not_found", Other: "Order {id} not found."})
+	b.MustAddMessages(language.French,
+		&i18n.Message{ID: "error.not_found", Other: "Commande {id} introuvable."},
+		&i18n.Message{ID: "Access denied.", Other: "Accès refusé."},
+	)
+	return b
+}
+
+func TestBundle(t *testing.T) {
+	dec := errdecode.New(rules, i18ndecode.Bundle(newBundle(t)))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want string
+	}{
+		{"no locale", context.Background(), &orderError{42}, "Order 42 not found."},
+		{"locale", errdecode.WithLocale(context.Background(), "fr-FR"), &orderError{42}, "Commande 42 introuvable."},
+		{"unsupported locale", errdecode.WithLocale(context.Background(), "ja"), errNotFound, "Order {id} not found."},
+		{"missing message", context.Background(), errDenied, "Access denied."},
+		{"translated message", errdecode.WithLocale(context.Background(), "fr"), errDenied, "Accès refusé."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dec.TranslateContext(tt.ctx, tt.err).Error(); got != tt.want {
+				t.Fatalf("unexpected message: got=%s want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+	dec := errdecode.New(rules, i18ndecode.Localizer(i18n.NewLocalizer(newBundle(t), "fr")))
+	if got, want := dec.Translate(errDenied).Error(), "Accès refusé."; got != want {
+		t.Fatalf("unexpected message: got=%s want=%s", got, want)
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/iamrgon/errdecode/internal/rpcdetails
+
+go 1.21
+
+require (
+	github.com/iamrgon/errdecode v0.1.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240814211410-ddb44dafa142
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,75 @@
+// Package rpcdetails builds the standard error details of classified errors,
+// as defined by google.golang.org/genproto/googleapis/rpc/errdetails, for
+// the RPC integrations, i.e., grpcdecode and connectdecode.
+package rpcdetails
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/iamrgon/errdecode"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// DefaultLocale is the locale of LocalizedMessage details when neither the
+// context nor the decoder has one.
+const DefaultLocale = "en-US"
+
+// Config configures the details built from classified errors.
+type Config struct {
+	// Domain is the domain of ErrorInfo details.
+	Domain string
+
+	// Locale returns the locale of LocalizedMessage details, if set.
+	// Otherwise, the locale of the context is used, see errdecode.WithLocale,
+	// then the default locale of the decoder, see errdecode.Locales, then
+	// DefaultLocale.
+	Locale func(ctx context.Context) string
+}
+
+func (c *Config) locale(ctx context.Context, d *errdecode.Decoder) string {
+	if c.Locale != nil {
+		return c.Locale(ctx)
+	}
+	if locale, ok := errdecode.LocaleFromContext(ctx); ok {
+		return locale
+	}
+	if locales := d.Locales(); len(locales) > 0 {
+		return locales[0]
+	}
+	return DefaultLocale
+}
+
+// Details returns the error details of an error classified by d with ctx:
+//
+//   - errdetails.ErrorInfo, with the code as reason, the domain of c and the
+//     fields of the error as metadata, see errdecode.Fielder
+//   - errdetails.LocalizedMessage, with the translated message and its locale
+//   - errdetails.RetryInfo, with the RetryAfter delay of retryable rules
+//   - errdetails.BadRequest, with a field violation for field errors, see
+//     errdecode.ClassifiedFieldError
+func Details(ctx context.Context, d *errdecode.Decoder, ce errdecode.ClassifiedError, rule errdecode.Rule, c *Config) []proto.Message {
+	info := &errdetails.ErrorInfo{Reason: strconv.Itoa(ce.Code()), Domain: c.Domain}
+	if fields := ce.Fields(); len(fields) > 0 {
+		info.Metadata = make(map[string]string, len(fields))
+		for k, v := range fields {
+			info.Metadata[k] = fmt.Sprint(v)
+		}
+	}
+	ds := []proto.Message{
+		info,
+		&errdetails.LocalizedMessage{Locale: c.locale(ctx, d), Message: ce.Error()},
+	}
+	if rule.Retryable && rule.RetryAfter > 0 {
+		ds = append(ds, &errdetails.RetryInfo{RetryDelay: durationpb.New(rule.RetryAfter)})
+	}
+	if fe, ok := ce.(errdecode.ClassifiedFieldError); ok {
+		ds = append(ds, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: fe.Field(), Description: ce.Error()}},
+		})
+	}
+	return ds
+}
@@ -0,0 +1,72 @@
+package errdecode
+
+import "context"
+
+// multiError is implemented by aggregates such as those returned by
+// errors.Join.
+type multiError interface {
+	Unwrap() []error
+}
+
+// TranslateAll decodes every error value aggregated by err, e.g., using
+// errors.Join, into a configured encoded mapping.
+//
+// Nested aggregates are flattened. Errors that cannot be classified are
+// returned as-is. If err is not an aggregate, a single translated error is
+// returned. Aggregates are always split, even if a rule matches them as a
+// whole, so every member is counted once, see Stats, and passed to the
+// OnTranslate hooks like an error given to Translate.
+func (d *Decoder) TranslateAll(err error) []error {
+	ctx := context.Background()
+	var translated []error
+	for _, e := range flatten(err) {
+		translated = append(translated, d.translate(ctx, e))
+	}
+	return translated
+}
+
+// flatten returns the non-nil errors aggregated by err, recursively, or err
+// itself if it is not an aggregate.
+func flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+	multi, ok := err.(multiError)
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range multi.Unwrap() {
+		errs = append(errs, flatten(e)...)
+	}
+	return errs
+}
+
+// translateJoined picks the highest-priority classification from the errors
+// aggregated by err. Ties are broken by the order of aggregation. Only the
+// picked member is reported to the OnDeprecated callback.
+//
+// Members are classified before the aggregate as a whole, since matchers
+// using errors.Is or errors.As see through aggregates and would otherwise
+// shadow the priority of the members.
+func (d *Decoder) translateJoined(ctx context.Context, err error) (error, bool) {
+	if _, ok := err.(multiError); !ok {
+		return nil, false
+	}
+
+	var best ClassifiedError
+	for _, e := range flatten(err) {
+		ce, ok := d.decode(ctx, e)
+		if !ok {
+			continue
+		}
+		if best == nil || d.idx.codeToRule[ce.Code()].Priority > d.idx.codeToRule[best.Code()].Priority {
+			best = ce
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+	d.warnDeprecated(ctx, best.Code(), best.Unwrap())
+	return best, true
+}
@@ -0,0 +1,125 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+var errMissingEmail = errors.New("missing email")
+var errMissingPassword = errors.New("missing password")
+var errAccountLocked = errors.New("account locked")
+
+func newJoinDecoder() *errdecode.Decoder {
+	return errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.email", Errors: []error{errMissingEmail}},
+		{Code: 1002, Message: "error.password", Errors: []error{errMissingPassword}},
+		{Code: 1003, Message: "error.locked", Errors: []error{errAccountLocked}, Priority: 10},
+	})
+}
+
+func TestDecoderTranslateAll(t *testing.T) {
+	errUnmatched := errors.New("unmatched")
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"nil", nil, nil},
+		{"single error", errMissingEmail, []string{"error.email"}},
+		{"joined errors", errors.Join(errMissingEmail, errMissingPassword), []string{"error.email", "error.password"}},
+		{"nested joins are flattened", errors.Join(errMissingEmail, errors.Join(errUnmatched, errAccountLocked)), []string{"error.email", "unmatched", "error.locked"}},
+		{"wrapped multiple errors", fmt.Errorf("%w, %w", errMissingPassword, errMissingEmail), []string{"error.password", "error.email"}},
+	}
+
+	dec := newJoinDecoder()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := dec.TranslateAll(tt.err)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("unexpected number of errors: got=%d want=%d", len(errs), len(tt.want))
+			}
+			for i, err := range errs {
+				if msg := err.Error(); msg != tt.want[i] {
+					t.Fatalf("unexpected message at %d: got='%s' want='%s'", i, msg, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecoderTranslateAllHooks(t *testing.T) {
+	errUnmatched := errors.New("unmatched")
+	var got []error
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.email", Errors: []error{errMissingEmail}},
+	}, errdecode.OnTranslate(func(_ context.Context, err, _ error) {
+		got = append(got, err)
+	}))
+
+	dec.TranslateAll(errors.Join(errMissingEmail, errUnmatched))
+	if len(got) != 2 || got[0] != errMissingEmail || got[1] != errUnmatched {
+		t.Fatalf("unexpected translated errors: got=%v want=%v", got, []error{errMissingEmail, errUnmatched})
+	}
+}
+
+func TestDecoderTranslateJoined(t *testing.T) {
+	errUnmatched := errors.New("unmatched")
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"first classification wins ties", errors.Join(errUnmatched, errMissingPassword, errMissingEmail), "error.password"},
+		{"highest priority wins", errors.Join(errMissingEmail, errAccountLocked), "error.locked"},
+		{"unclassified aggregate is returned as-is", errors.Join(errUnmatched), "unmatched"},
+	}
+
+	dec := newJoinDecoder()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if msg := dec.Translate(tt.err).Error(); msg != tt.want {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoderTranslateJoinedBeforeWhole(t *testing.T) {
+	errJoined := errors.Join(errMissingEmail, errAccountLocked)
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.email", Match: func(err error) bool { return errors.Is(err, errMissingEmail) }},
+		{Code: 1003, Message: "error.locked", Errors: []error{errAccountLocked}, Priority: 10},
+		{Code: 1004, Message: "error.form", Errors: []error{errJoined}},
+	})
+
+	tests := []struct {
+		name    string
+		err     error
+		want    string
+		wantAll []string
+	}{
+		{"matcher seeing through the join", errors.Join(errMissingEmail, errAccountLocked), "error.locked", []string{"error.email", "error.locked"}},
+		{"rule listing the join", errJoined, "error.locked", []string{"error.email", "error.locked"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if msg := dec.Translate(tt.err).Error(); msg != tt.want {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, tt.want)
+			}
+			errs := dec.TranslateAll(tt.err)
+			if len(errs) != len(tt.wantAll) {
+				t.Fatalf("unexpected number of errors: got=%d want=%d", len(errs), len(tt.wantAll))
+			}
+			for i, err := range errs {
+				if msg := err.Error(); msg != tt.wantAll[i] {
+					t.Fatalf("unexpected message at %d: got='%s' want='%s'", i, msg, tt.wantAll[i])
+				}
+			}
+		})
+	}
+}
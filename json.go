@@ -0,0 +1,21 @@
+package errdecode
+
+import "encoding/json"
+
+// WithCause is used to include the message of the underlying error when
+// classified errors are marshaled to JSON.
+//
+// The underlying error may leak implementation details, so it should only be
+// enabled for internal consumers.
+func WithCause() Option {
+	return func(d *Decoder) {
+		d.withCause = true
+		d.options = append(d.options, "WithCause")
+	}
+}
+
+// MarshalJSON satisfies the json.Marshaler interface, writing the error as
+// its ErrorPayload, see NewErrorPayload.
+func (e *matchedError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(NewErrorPayload(e))
+}
@@ -0,0 +1,44 @@
+package errdecode_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestClassifiedErrorMarshalJSON(t *testing.T) {
+	rules := []errdecode.Rule{{
+		Code:    codeCustomError,
+		Message: "The {kind} does not exist.",
+		Errors:  []error{errClient1},
+		Match:   func(err error) bool { _, ok := err.(*notFoundError); return ok },
+	}}
+	dec := errdecode.New(rules)
+	withCause := errdecode.New(rules, errdecode.WithCause())
+	rules[0].Severity = errdecode.SeverityWarning
+	withSeverity := errdecode.New(rules)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"without details", dec.Translate(errClient1), `{"code":1002,"message":"The {kind} does not exist."}`},
+		{"with details", dec.Translate(&notFoundError{"user", 42}), `{"code":1002,"message":"The user does not exist.","details":{"id":42,"kind":"user"}}`},
+		{"with severity", withSeverity.Translate(errClient1), `{"code":1002,"message":"The {kind} does not exist.","severity":"warning"}`},
+		{"with cause", withCause.Translate(errClient1), `{"code":1002,"message":"The {kind} does not exist.","cause":"client error 1"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.err)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(b) != tt.want {
+				t.Fatalf("unexpected JSON: got=%s want=%s", b, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package errdecode
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Lazy is used to defer the translation of messages until they are needed,
+// i.e., when Error is called or when the error is rendered with Render, so
+// that errors crossing goroutines or services are localized at the edge, in
+// the locale of the final recipient.
+//
+// Fields are collected when errors are translated, while messages are
+// translated with a context only carrying the locale they are rendered to,
+// see WithLocale. Error renders the message in the default locale, see
+// Locales, and caches it.
+func Lazy() Option {
+	return func(d *Decoder) {
+		d.lazy = true
+		d.options = append(d.options, "Lazy")
+	}
+}
+
+// lazyMessage is the message of an error translated by a lazy decoder.
+type lazyMessage struct {
+	decoder *Decoder
+	once    sync.Once
+}
+
+// Render returns the message of the error translated to the given locale, or
+// to the default locale if locale is empty.
+func (e *matchedError) Render(locale string) string {
+	if e.lazy == nil {
+		return e.message()
+	}
+	ctx := context.Background()
+	if locale != "" {
+		ctx = WithLocale(ctx, locale)
+	}
+	return e.lazy.decoder.render(ctx, e.code, e.key, e.fields)
+}
+
+// Render returns the message of the first classified error in the wrap chain
+// of err translated to the given locale, see Lazy. Errors translated eagerly
+// keep their message. It returns an empty string if err is not classified,
+// like MessageOf.
+func Render(err error, locale string) string {
+	var ce ClassifiedError
+	if !errors.As(err, &ce) {
+		return ""
+	}
+	if r, ok := ce.(interface{ Render(locale string) string }); ok {
+		return r.Render(locale)
+	}
+	return ce.Error()
+}
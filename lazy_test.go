@@ -0,0 +1,85 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestLazy(t *testing.T) {
+	errLazy := errors.New("lazy")
+	translated := 0
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "error.not_found", Errors: []error{errLazy}}},
+		errdecode.Lazy(),
+		errdecode.Locales("en"),
+		errdecode.MessageContext(func(ctx context.Context, msg string) string {
+			translated++
+			if locale, _ := errdecode.LocaleFromContext(ctx); locale == "fr" {
+				return "Introuvable."
+			}
+			return "Not found."
+		}),
+	)
+
+	err := dec.TranslateContext(errdecode.WithLocale(context.Background(), "fr"), errLazy)
+	if translated != 0 {
+		t.Fatalf("unexpected translations: got=%d want=0", translated)
+	}
+	if got, want := errdecode.Render(err, "fr"), "Introuvable."; got != want {
+		t.Fatalf("unexpected message: got=%s want=%s", got, want)
+	}
+	err = fmt.Errorf("get order: %w", err)
+	for i := 0; i < 2; i++ {
+		if got, want := errdecode.MessageOf(err), "Not found."; got != want {
+			t.Fatalf("unexpected default message: got=%s want=%s", got, want)
+		}
+	}
+	if translated != 2 {
+		t.Fatalf("unexpected translations: got=%d want=2", translated)
+	}
+}
+
+func TestRender(t *testing.T) {
+	errEager := errors.New("eager")
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "Not found.", Errors: []error{errEager}}})
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"eager", dec.Translate(errEager), "Not found."},
+		{"unclassified", errEager, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errdecode.Render(tt.err, "fr"); got != tt.want {
+				t.Fatalf("unexpected message: got=%s want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLazyFieldError(t *testing.T) {
+	dec := errdecode.New(nil,
+		errdecode.Lazy(),
+		errdecode.FieldRules(errdecode.FieldRule{Kind: "required", Code: 1001, Message: "error.required"}),
+		errdecode.LocaleMessages("fr", errdecode.Messages{"error.required": "Le champ {field} est requis."}),
+	)
+	err := dec.Translate(errdecode.NewFieldError("email", "required"))
+	if got, want := errdecode.Render(err, "fr"), "Le champ email est requis."; got != want {
+		t.Fatalf("unexpected message: got=%s want=%s", got, want)
+	}
+}
+
+func TestLazyTranslateCode(t *testing.T) {
+	errLazy := errors.New("lazy")
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "Not found.", Errors: []error{errLazy}}}, errdecode.Lazy())
+	if code, msg, ok := dec.TranslateCode(errLazy); !ok || code != 1001 || msg != "Not found." {
+		t.Fatalf("unexpected classification: got=%d/%s/%t want=1001/Not found./true", code, msg, ok)
+	}
+}
@@ -0,0 +1,184 @@
+package errdecode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+type localeKey struct{}
+
+// WithLocale returns a copy of ctx carrying the BCP 47 language tag of the
+// locale errors should be translated to, e.g., "fr-FR".
+//
+// Translators configured with MessageContext retrieve it with
+// LocaleFromContext.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale attached to ctx by WithLocale, if
+// any.
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeKey{}).(string)
+	return locale, ok
+}
+
+// Locales is used to declare the BCP 47 language tags of the locales the
+// message translator supports, the first one being the default locale, e.g.,
+// for content negotiation.
+func Locales(tags ...string) Option {
+	return func(d *Decoder) {
+		d.locales = append(d.locales, tags...)
+		d.options = append(d.options, "Locales")
+	}
+}
+
+// Locales returns the locales declared with the Locales option, the default
+// locale first.
+func (d *Decoder) Locales() []string {
+	locales := make([]string, len(d.locales))
+	copy(locales, d.locales)
+	return locales
+}
+
+// Messages maps message keys, i.e., the messages of rules, to their
+// translation in a locale.
+type Messages map[string]string
+
+// LocaleMessages is used to translate messages to the given locale with msgs,
+// so that a single decoder serves every locale. It is used once per locale:
+//
+//	decoder := errdecode.New(rules,
+//		errdecode.Locales("en-US", "fr-FR"),
+//		errdecode.LocaleMessages("en-US", enMessages),
+//		errdecode.LocaleMessages("fr-FR", frMessages),
+//	)
+//
+// Messages are translated to the locale attached to the context passed to
+// TranslateContext, see WithLocale, or to its base language, e.g., "fr" for
+// "fr-CA". Keys missing from the catalog of the locale, or requests without a
+// catalog, use the catalog of the default locale, see Locales. Keys missing
+// from every catalog are used as messages.
+//
+// Translations may vary with a count field in the ICU plural syntax, with the
+// plural categories of the locale, see PluralCategory:
+//
+//	{count, plural, one {# file failed.} other {# files failed.}}
+//
+// It replaces the message translator of the decoder.
+func LocaleMessages(locale string, msgs Messages) Option {
+	return func(d *Decoder) {
+		d.catalogs.set(locale, msgs)
+		d.msgTranslator = d.translateLocale
+		d.options = append(d.options, "LocaleMessages")
+	}
+}
+
+// LoadLocale reads the catalog of the locale lang from a JSON object mapping
+// message keys to their translation, e.g., locales/fr-FR.json:
+//
+//	{
+//		"error.not_found": "Commande introuvable.",
+//		"Access denied.": "Accès refusé."
+//	}
+//
+// and returns the option attaching it to a decoder, see LocaleMessages.
+func LoadLocale(lang string, r io.Reader) (Option, error) {
+	msgs, err := readLocaleJSON(r)
+	if err != nil {
+		return nil, fmt.Errorf("errdecode: decode %s locale: %w", lang, err)
+	}
+	return LocaleMessages(lang, msgs), nil
+}
+
+// ReadLocale reads a locale catalog in the format given by a file extension,
+// i.e., ".json" for LoadLocale, ".po" for LoadPO or ".mo" for LoadMO, e.g.,
+// for tooling checking translations, see CheckCoverage.
+func ReadLocale(r io.Reader, ext string) (Messages, error) {
+	switch ext {
+	case ".json":
+		return readLocaleJSON(r)
+	case ".po":
+		return readPO(r)
+	case ".mo":
+		return readMO(r)
+	}
+	return nil, fmt.Errorf("errdecode: unsupported locale format %q", ext)
+}
+
+func readLocaleJSON(r io.Reader) (Messages, error) {
+	var msgs Messages
+	if err := json.NewDecoder(r).Decode(&msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// SetLocale replaces the catalog of the given locale at runtime, e.g., to
+// ship translation fixes without restarting the service, see LocaleWatcher.
+// It is safe for concurrent use with translations.
+//
+// Catalogs are only used by decoders translating messages with locale
+// catalogs, see LocaleMessages.
+func (d *Decoder) SetLocale(locale string, msgs Messages) {
+	d.catalogs.set(locale, msgs)
+}
+
+// translateLocale translates a message key with the catalogs of the decoder.
+func (d *Decoder) translateLocale(ctx context.Context, key string) string {
+	if locale, ok := LocaleFromContext(ctx); ok {
+		if msg, ok := d.localeCatalog(locale)[key]; ok {
+			return msg
+		}
+	}
+	if len(d.locales) > 0 {
+		if msg, ok := d.localeCatalog(d.locales[0])[key]; ok {
+			return msg
+		}
+	}
+	return key
+}
+
+// localeCatalogs holds the catalogs of a decoder by lowercase locale.
+type localeCatalogs struct {
+	mu       sync.RWMutex
+	byLocale map[string]Messages
+}
+
+func (c *localeCatalogs) set(locale string, msgs Messages) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.byLocale == nil {
+		c.byLocale = make(map[string]Messages)
+	}
+	c.byLocale[strings.ToLower(locale)] = msgs
+}
+
+func (c *localeCatalogs) empty() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.byLocale) == 0
+}
+
+// localeCatalog returns the catalog of locale or of its closest parent
+// locale, e.g., "zh-Hant" then "zh" for "zh-Hant-TW", if any.
+func (d *Decoder) localeCatalog(locale string) Messages {
+	c := d.catalogs
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	locale = strings.ToLower(locale)
+	for {
+		if msgs, ok := c.byLocale[locale]; ok {
+			return msgs
+		}
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			return nil
+		}
+		locale = locale[:i]
+	}
+}
@@ -0,0 +1,135 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestLocale(t *testing.T) {
+	errLocale := errors.New("locale")
+	messages := map[string]string{"en-US": "Not found.", "fr-FR": "Introuvable."}
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.not_found", Errors: []error{errLocale}},
+	},
+		errdecode.Locales("en-US", "fr-FR"),
+		errdecode.MessageContext(func(ctx context.Context, msg string) string {
+			locale, ok := errdecode.LocaleFromContext(ctx)
+			if !ok {
+				locale = "en-US"
+			}
+			return messages[locale]
+		}),
+	)
+
+	if got := dec.Locales(); len(got) != 2 || got[0] != "en-US" || got[1] != "fr-FR" {
+		t.Fatalf("unexpected locales: got=%v want=[en-US fr-FR]", got)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no locale", context.Background(), "Not found."},
+		{"locale", errdecode.WithLocale(context.Background(), "fr-FR"), "Introuvable."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dec.TranslateContext(tt.ctx, errLocale).Error(); got != tt.want {
+				t.Fatalf("unexpected message: got=%s want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleMessages(t *testing.T) {
+	errLocale := errors.New("locale")
+	errUntranslated := errors.New("untranslated")
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.not_found", Errors: []error{errLocale}},
+		{Code: 1002, Message: "Try again.", Errors: []error{errUntranslated}},
+	},
+		errdecode.Locales("en-US", "fr"),
+		errdecode.LocaleMessages("en-US", errdecode.Messages{"error.not_found": "Not found."}),
+		errdecode.LocaleMessages("fr", errdecode.Messages{}),
+		errdecode.LocaleMessages("fr-CA", errdecode.Messages{"error.not_found": "Introuvable."}),
+	)
+
+	tests := []struct {
+		name   string
+		locale string
+		err    error
+		want   string
+	}{
+		{"no locale", "", errLocale, "Not found."},
+		{"locale", "fr-CA", errLocale, "Introuvable."},
+		{"case-insensitive locale", "FR-ca", errLocale, "Introuvable."},
+		{"parent locale", "fr-CA-x-quebec", errLocale, "Introuvable."},
+		{"missing key", "fr", errLocale, "Not found."},
+		{"unsupported locale", "ja-JP", errLocale, "Not found."},
+		{"untranslated", "fr-CA", errUntranslated, "Try again."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.locale != "" {
+				ctx = errdecode.WithLocale(ctx, tt.locale)
+			}
+			if got := dec.TranslateContext(ctx, tt.err).Error(); got != tt.want {
+				t.Fatalf("unexpected message: got=%s want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLocale(t *testing.T) {
+	errLocale := errors.New("locale")
+	fr, err := errdecode.LoadLocale("fr-FR", strings.NewReader(`{"error.not_found": "Introuvable."}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "error.not_found", Errors: []error{errLocale}}}, fr)
+
+	ctx := errdecode.WithLocale(context.Background(), "fr-FR")
+	if got, want := dec.TranslateContext(ctx, errLocale).Error(), "Introuvable."; got != want {
+		t.Fatalf("unexpected message: got=%s want=%s", got, want)
+	}
+}
+
+func TestLoadLocaleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"malformed", `{"error.not_found": `},
+		{"not an object", `["Introuvable."]`},
+		{"not a string", `{"error.not_found": {"one": "Introuvable."}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := errdecode.LoadLocale("fr-FR", strings.NewReader(tt.json)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	errLocale := errors.New("locale")
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "error.not_found", Errors: []error{errLocale}}},
+		errdecode.LocaleMessages("fr", errdecode.Messages{"error.not_found": "Introuvable."}),
+	)
+	dec.SetLocale("FR", errdecode.Messages{"error.not_found": "Commande introuvable."})
+
+	ctx := errdecode.WithLocale(context.Background(), "fr")
+	if got, want := dec.TranslateContext(ctx, errLocale).Error(), "Commande introuvable."; got != want {
+		t.Fatalf("unexpected message: got=%s want=%s", got, want)
+	}
+}
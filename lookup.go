@@ -0,0 +1,76 @@
+package errdecode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ErrorForCode returns a classified error built from the rule with the given
+// code, e.g., to rehydrate a code received from a queue message or an API
+// payload. The returned error has no underlying cause.
+//
+// It returns false if no rule has the given code.
+func (d *Decoder) ErrorForCode(code int) (error, bool) {
+	if _, ok := d.idx.codeToRule[code]; !ok {
+		return nil, false
+	}
+	return d.WrapError(code, nil), true
+}
+
+// NewError returns an error pre-classified by the rule with the given code,
+// so application code can return classified errors at the source while
+// messages stay centralized in the rule set.
+//
+// If no rule has the given code, the returned error is not classified, so
+// that its internal message reporting the unknown code is never shown to
+// clients, see Code.
+func (d *Decoder) NewError(code int) error {
+	return d.WrapError(code, nil)
+}
+
+// WrapError is like NewError, but the returned error wraps cause.
+func (d *Decoder) WrapError(code int, cause error) error {
+	rule, ok := d.idx.codeToRule[code]
+	if !ok {
+		if cause == nil {
+			return fmt.Errorf("errdecode: unknown code %d", code)
+		}
+		return fmt.Errorf("errdecode: unknown code %d: %w", code, cause)
+	}
+	ctx := context.Background()
+	d.warnDeprecated(ctx, code, cause)
+	return d.newMatchedError(ctx, classification{code: code, key: rule.Message, err: cause})
+}
+
+// RuleFor returns the rule with the given code. It returns false if no rule
+// has the given code.
+func (d *Decoder) RuleFor(code int) (Rule, bool) {
+	rule, ok := d.idx.codeToRule[code]
+	return rule, ok
+}
+
+// Rules returns a copy of the rules the decoder was created with, in their
+// original order.
+func (d *Decoder) Rules() []Rule {
+	rules := make([]Rule, len(d.idx.rules))
+	copy(rules, d.idx.rules)
+	return rules
+}
+
+// Codes returns the codes of every rule, in ascending order.
+func (d *Decoder) Codes() []int {
+	codes := make([]int, 0, len(d.idx.codeToRule))
+	for code := range d.idx.codeToRule {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+// MessageFor returns the message of the rule with the given code, before
+// translation. It returns false if no rule has the given code.
+func (d *Decoder) MessageFor(code int) (string, bool) {
+	rule, ok := d.idx.codeToRule[code]
+	return rule.Message, ok
+}
@@ -0,0 +1,135 @@
+package errdecode_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestDecoderErrorForCode(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}, Retryable: true},
+	}, errdecode.Message(func(msg string) string { return "translated " + msg }))
+
+	err, ok := dec.ErrorForCode(codeClientError)
+	if !ok {
+		t.Fatalf("expected code to be known")
+	}
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a classified error")
+	}
+	if ce.Code() != codeClientError || ce.Error() != "translated error.client" || !ce.Retryable() {
+		t.Fatalf("unexpected classified error: code=%d msg='%s' retryable=%t", ce.Code(), ce.Error(), ce.Retryable())
+	}
+	if ce.Unwrap() != nil {
+		t.Fatalf("expected no underlying cause got=%v", ce.Unwrap())
+	}
+
+	if _, ok := dec.ErrorForCode(codeCatchAll); ok {
+		t.Fatalf("expected unknown code to be reported")
+	}
+}
+
+func TestDecoderIntrospection(t *testing.T) {
+	rules := []errdecode.Rule{
+		{Code: codeWrappedError, Message: "error.wrapped"},
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+	}
+	dec := errdecode.New(rules)
+
+	got := dec.Rules()
+	if len(got) != 2 || got[0].Code != codeWrappedError || got[1].Code != codeClientError {
+		t.Fatalf("expected rules in original order got=%v", got)
+	}
+	got[0].Message = "mutated"
+	if msg, _ := dec.MessageFor(codeWrappedError); msg != "error.wrapped" {
+		t.Fatalf("expected returned rules to be a copy")
+	}
+
+	codes := dec.Codes()
+	if len(codes) != 2 || codes[0] != codeClientError || codes[1] != codeWrappedError {
+		t.Fatalf("expected sorted codes got=%v", codes)
+	}
+
+	if msg, ok := dec.MessageFor(codeClientError); !ok || msg != "error.client" {
+		t.Fatalf("unexpected message: got='%s' ok=%t", msg, ok)
+	}
+	if _, ok := dec.MessageFor(codeCatchAll); ok {
+		t.Fatalf("expected unknown code to be reported")
+	}
+
+	if rule, ok := dec.RuleFor(codeWrappedError); !ok || rule.Message != "error.wrapped" {
+		t.Fatalf("unexpected rule: got=%v ok=%t", rule, ok)
+	}
+	if _, ok := dec.RuleFor(codeCatchAll); ok {
+		t.Fatalf("expected unknown code to be reported")
+	}
+}
+
+func TestDecoderNewErrorUnknownCode(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client"},
+	})
+	cause := errors.New("quota exceeded")
+
+	tests := []struct {
+		name      string
+		err       error
+		wantMsg   string
+		wantCause error
+	}{
+		{"without cause", dec.NewError(codeCatchAll), "errdecode: unknown code 1000", nil},
+		{"with cause", dec.WrapError(codeCatchAll, cause), "errdecode: unknown code 1000: quota exceeded", cause},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := errdecode.Code(tt.err); code != 0 {
+				t.Fatalf("unexpected code: got=%d want=0", code)
+			}
+			if errdecode.IsCode(tt.err, codeCatchAll) {
+				t.Fatalf("expected unknown code not to be reported")
+			}
+			if tt.err.Error() != tt.wantMsg {
+				t.Fatalf("unexpected message: got='%s' want='%s'", tt.err.Error(), tt.wantMsg)
+			}
+			if errors.Unwrap(tt.err) != tt.wantCause {
+				t.Fatalf("unexpected cause: got=%v want=%v", errors.Unwrap(tt.err), tt.wantCause)
+			}
+		})
+	}
+}
+
+func TestDecoderNewError(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Retryable: true},
+	})
+	cause := errors.New("quota exceeded")
+
+	tests := []struct {
+		name      string
+		err       error
+		wantMsg   string
+		wantCause error
+	}{
+		{"without cause", dec.NewError(codeClientError), "error.client", nil},
+		{"with cause", dec.WrapError(codeClientError, cause), "error.client", cause},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ce errdecode.ClassifiedError
+			if !errors.As(tt.err, &ce) {
+				t.Fatalf("expected a classified error")
+			}
+			if ce.Error() != tt.wantMsg {
+				t.Fatalf("unexpected message: got='%s' want='%s'", ce.Error(), tt.wantMsg)
+			}
+			if ce.Unwrap() != tt.wantCause {
+				t.Fatalf("unexpected cause: got=%v want=%v", ce.Unwrap(), tt.wantCause)
+			}
+		})
+	}
+}
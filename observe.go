@@ -0,0 +1,21 @@
+package errdecode
+
+import "context"
+
+// TranslationFunc is called after every translation, see OnTranslate. The
+// translated error is a ClassifiedError, unless err could not be classified,
+// in which case it is err itself.
+type TranslationFunc func(ctx context.Context, err, translated error)
+
+// OnTranslate is used to be notified of every translation made by Translate,
+// TranslateContext and, once per member, TranslateAll, e.g., to record
+// classifications into traces, logs or metrics. It may be used several times, hooks being called in order.
+// Hooks are not called for nil errors.
+//
+// Hooks are called synchronously, so they should be fast.
+func OnTranslate(fn TranslationFunc) Option {
+	return func(d *Decoder) {
+		d.onTranslate = append(d.onTranslate, fn)
+		d.options = append(d.options, "OnTranslate")
+	}
+}
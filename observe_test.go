@@ -0,0 +1,37 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestOnTranslate(t *testing.T) {
+	errObserved := errors.New("observed")
+	errOther := errors.New("other")
+	var calls []string
+	hook := func(name string) errdecode.TranslationFunc {
+		return func(ctx context.Context, err, translated error) {
+			calls = append(calls, name+":"+err.Error()+":"+translated.Error())
+		}
+	}
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "Observed.", Errors: []error{errObserved}}},
+		errdecode.OnTranslate(hook("first")),
+		errdecode.OnTranslate(hook("second")),
+	)
+
+	dec.Translate(errObserved)
+	dec.TranslateContext(context.Background(), errOther)
+
+	want := []string{"first:observed:Observed.", "second:observed:Observed.", "first:other:other", "second:other:other"}
+	if len(calls) != len(want) {
+		t.Fatalf("unexpected calls: got=%q want=%q", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("unexpected call %d: got=%s want=%s", i, calls[i], want[i])
+		}
+	}
+}
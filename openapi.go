@@ -0,0 +1,66 @@
+package errdecode
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// ExportOpenAPI writes OpenAPI 3.1 components describing the error responses
+// of the decoder, so that every code shows up in the API spec:
+//
+//	{
+//		"components": {
+//			"schemas": {
+//				"Error": {...},
+//				"ErrorCode": {"type": "integer", "oneOf": [{"const": 1001, "description": "..."}]}
+//			}
+//		}
+//	}
+//
+// The Error schema describes the JSON form of ErrorPayload, as written by
+// HTTP servers and read by ParseResponse. Descriptions are the translated rule messages.
+func (d *Decoder) ExportOpenAPI(w io.Writer) error {
+	codes := d.Codes()
+	oneOf := make([]interface{}, 0, len(codes))
+	for _, code := range codes {
+		msg, _ := d.MessageFor(code)
+		oneOf = append(oneOf, map[string]interface{}{
+			"const":       code,
+			"description": d.msgTranslator(context.Background(), msg),
+		})
+	}
+
+	errorCode := map[string]interface{}{
+		"type":        "integer",
+		"description": "Identifier for a class of errors.",
+	}
+	if len(oneOf) > 0 {
+		errorCode["oneOf"] = oneOf
+	}
+
+	doc := map[string]interface{}{
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"ErrorCode": errorCode,
+				"Error": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"code", "message"},
+					"properties": map[string]interface{}{
+						"code":           map[string]interface{}{"$ref": "#/components/schemas/ErrorCode"},
+						"message":        map[string]interface{}{"type": "string"},
+						"severity":       map[string]interface{}{"type": "string", "enum": []string{"info", "warning", "error", "critical"}},
+						"details":        map[string]interface{}{"type": "object", "additionalProperties": true},
+						"docs_url":       map[string]interface{}{"type": "string", "format": "uri"},
+						"correlation_id": map[string]interface{}{"type": "string"},
+						"cause":          map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,53 @@
+package errdecode_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestDecoderExportOpenAPI(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1002, Message: "error.unavailable"},
+		{Code: 1001, Message: "error.auth"},
+	}, errdecode.Message(func(msg string) string { return "translated " + msg }))
+
+	var buf bytes.Buffer
+	if err := dec.ExportOpenAPI(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc struct {
+		Components struct {
+			Schemas struct {
+				Error struct {
+					Properties map[string]struct {
+						Ref string `json:"$ref"`
+					} `json:"properties"`
+				} `json:"Error"`
+				ErrorCode struct {
+					OneOf []struct {
+						Const       int    `json:"const"`
+						Description string `json:"description"`
+					} `json:"oneOf"`
+				} `json:"ErrorCode"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	schemas := doc.Components.Schemas
+	if ref := schemas.Error.Properties["code"].Ref; ref != "#/components/schemas/ErrorCode" {
+		t.Fatalf("unexpected code reference: got='%s'", ref)
+	}
+	if n := len(schemas.ErrorCode.OneOf); n != 2 {
+		t.Fatalf("unexpected number of codes: got=%d want=2", n)
+	}
+	if c := schemas.ErrorCode.OneOf[0]; c.Const != 1001 || c.Description != "translated error.auth" {
+		t.Fatalf("unexpected first code: %+v", c)
+	}
+}
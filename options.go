@@ -1,5 +1,10 @@
 package errdecode
 
+import (
+	"errors"
+	"reflect"
+)
+
 // Option sets an optional parameter for decoders.
 type Option func(*Decoder)
 
@@ -8,7 +13,19 @@ type Option func(*Decoder)
 // This is particular useful for integrating transformations or performing
 // key-based lookups, e.g., locale-based text or remote lookups.
 func Message(t MessageTranslatorFunc) Option {
-	return func(d *Decoder) { d.msgTranslator = t }
+	return func(d *Decoder) {
+		d.msgTranslator = t.withContext()
+		d.options = append(d.options, "Message")
+	}
+}
+
+// MessageContext is like Message, but the translator has access to the
+// context passed to TranslateContext.
+func MessageContext(t ContextMessageTranslatorFunc) Option {
+	return func(d *Decoder) {
+		d.msgTranslator = t
+		d.options = append(d.options, "MessageContext")
+	}
 }
 
 // Encoder is used to provide an error classifier.
@@ -16,7 +33,21 @@ func Message(t MessageTranslatorFunc) Option {
 // It is most useful in scenarios where errors need to be checked in a variety
 // of ways, e.g., custom error wrapping.
 func Encoder(enc EncoderFunc) Option {
-	return func(d *Decoder) { d.encoder = enc }
+	return func(d *Decoder) {
+		d.encoder = enc.withContext()
+		d.customEncoder = true
+		d.options = append(d.options, "Encoder")
+	}
+}
+
+// EncoderContext is like Encoder, but the classifier has access to the
+// context passed to TranslateContext.
+func EncoderContext(enc ContextEncoderFunc) Option {
+	return func(d *Decoder) {
+		d.encoder = enc
+		d.customEncoder = true
+		d.options = append(d.options, "EncoderContext")
+	}
 }
 
 // A mirror effect.
@@ -27,21 +58,23 @@ func defaultMessageTranslator(msg string) string {
 // Returns the default encoder, which performs the following checks:
 //
 //	1. Compare the error value to classified error values
-//	2. Pass the error value to classified matchers
+//	2. Dispatch the error value to the rules declaring its type
+//	3. Pass the error value to classified matchers, in rule order
 //
 // If any are true, the classification code and message are returned.
 //
 // In the case of an unclassified error, the zero values are used.
-func newDefaultEncoder(rs []Rule) EncoderFunc {
-	idx := newRuleIndex(rs)
-
+func newDefaultEncoder(idx *ruleIndex) EncoderFunc {
 	return func(err error) (int, string) {
-		if code, ok := idx.errToCode[err]; ok {
-			return code, idx.codeToMessage[code]
+		if code, ok := idx.matchError(err); ok {
+			return code, idx.codeToRule[code].Message
+		}
+		if code, ok := idx.matchTyped(err); ok {
+			return code, idx.codeToRule[code].Message
 		}
-		for code, matcher := range idx.codeToMatcher {
-			if isMatch := matcher(err); isMatch {
-				return code, idx.codeToMessage[code]
+		for _, m := range idx.matchers {
+			if isMatch := m.match(err); isMatch {
+				return m.code, idx.codeToRule[m.code].Message
 			}
 		}
 		return 0, "" // unclassified error
@@ -51,23 +84,36 @@ func newDefaultEncoder(rs []Rule) EncoderFunc {
 // ruleIndex represents various convenience maps derived from a rules slice.
 // It provides constant-time lookups for fields of importance.
 type ruleIndex struct {
-	codeToMatcher map[int]MatcherFunc
-	codeToMessage map[int]string
-	errToCode     map[error]int
+	rules       []Rule
+	matchers    []codedMatcher
+	codeToRule  map[int]Rule
+	errToCode   map[error]int
+	typeToRules map[reflect.Type][]Rule
+}
+
+// codedMatcher pairs a matcher with the code of its rule.
+type codedMatcher struct {
+	code  int
+	match MatcherFunc
 }
 
 // newRuleIndex create indexes from the provided rules.
 func newRuleIndex(rs []Rule) *ruleIndex {
-	codeToMatcher := make(map[int]MatcherFunc)
-	codeToMessage := make(map[int]string)
+	var matchers []codedMatcher
+	codeToRule := make(map[int]Rule)
 	errToCode := make(map[error]int)
+	typeToRules := make(map[reflect.Type][]Rule)
 
 	for _, rule := range rs {
 		code := rule.Code
 
-		codeToMessage[code] = rule.Message
-		if rule.Match != nil {
-			codeToMatcher[code] = rule.Match
+		codeToRule[code] = rule
+		for _, t := range rule.Types {
+			typeToRules[t] = append(typeToRules[t], rule)
+		}
+		// Rules sharing a code, e.g., merged presets, keep their own matcher.
+		if rule.Match != nil && len(rule.Types) == 0 {
+			matchers = append(matchers, codedMatcher{code, rule.Match})
 		}
 
 		for _, e := range rule.Errors {
@@ -75,5 +121,34 @@ func newRuleIndex(rs []Rule) *ruleIndex {
 		}
 	}
 
-	return &ruleIndex{codeToMatcher, codeToMessage, errToCode}
+	rules := make([]Rule, len(rs))
+	copy(rules, rs)
+
+	return &ruleIndex{rules, matchers, codeToRule, errToCode, typeToRules}
+}
+
+// matchError returns the code of the rule listing err in its Errors. Errors
+// of non-comparable values, e.g., structs holding a slice, never match.
+func (idx *ruleIndex) matchError(err error) (int, bool) {
+	if !reflect.ValueOf(err).Comparable() {
+		return 0, false
+	}
+	code, ok := idx.errToCode[err]
+	return code, ok
+}
+
+// matchTyped dispatches each error in the wrap chain of err to the rules
+// declaring its concrete type.
+func (idx *ruleIndex) matchTyped(err error) (int, bool) {
+	if len(idx.typeToRules) == 0 {
+		return 0, false
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		for _, rule := range idx.typeToRules[reflect.TypeOf(e)] {
+			if rule.Match == nil || rule.Match(err) {
+				return rule.Code, true
+			}
+		}
+	}
+	return 0, false
 }
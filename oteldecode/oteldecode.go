@@ -0,0 +1,63 @@
+// Package oteldecode records the classifications of an errdecode.Decoder
+// onto OpenTelemetry trace spans:
+//
+//	decoder := errdecode.New(rules, oteldecode.RecordSpans())
+//	err = decoder.TranslateContext(ctx, err)
+//
+// Every error translated with the context of a recording span is recorded as
+// an exception event of the span, with the attributes of its classification,
+// see the Attr constants.
+package oteldecode
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iamrgon/errdecode"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes of the exception events of classified errors.
+const (
+	AttrCode       = attribute.Key("errdecode.code")
+	AttrMessageKey = attribute.Key("errdecode.message_key")
+	AttrSeverity   = attribute.Key("errdecode.severity")
+	AttrRetryable  = attribute.Key("errdecode.retryable")
+)
+
+// RecordSpans is used to record translated errors onto the span of the
+// context passed to TranslateContext, if any, see errdecode.OnTranslate.
+//
+// The status of the span is set to error, with the translated message as
+// description, for errors of severity error or critical, and for
+// unclassified errors, which are unexpected. Errors of lower severity, e.g.,
+// a validation error, are only recorded as events.
+func RecordSpans() errdecode.Option {
+	return errdecode.OnTranslate(func(ctx context.Context, err, translated error) {
+		span := trace.SpanFromContext(ctx)
+		if !span.IsRecording() {
+			return
+		}
+
+		var ce errdecode.ClassifiedError
+		if !errors.As(translated, &ce) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "unclassified error")
+			return
+		}
+		attrs := []attribute.KeyValue{
+			AttrCode.Int(ce.Code()),
+			AttrMessageKey.String(ce.MessageKey()),
+			AttrRetryable.Bool(ce.Retryable()),
+		}
+		if ce.Severity() != errdecode.SeverityUnspecified {
+			attrs = append(attrs, AttrSeverity.String(ce.Severity().String()))
+		}
+		span.RecordError(err, trace.WithAttributes(attrs...))
+		if ce.Severity() >= errdecode.SeverityError {
+			span.SetStatus(codes.Error, ce.Error())
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package oteldecode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/oteldecode"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var (
+	errInvalid  = errors.New("invalid email")
+	errDatabase = errors.New("database unavailable")
+	errUnknown  = errors.New("boom")
+)
+
+func TestRecordSpans(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.invalid", Errors: []error{errInvalid}, Severity: errdecode.SeverityWarning},
+		{Code: 1002, Message: "Try again later.", Errors: []error{errDatabase}, Severity: errdecode.SeverityCritical, Retryable: true},
+	}, oteldecode.RecordSpans())
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+		wantAttrs  map[attribute.Key]attribute.Value
+	}{
+		{"warning", errInvalid, codes.Unset, map[attribute.Key]attribute.Value{
+			oteldecode.AttrCode:       attribute.IntValue(1001),
+			oteldecode.AttrMessageKey: attribute.StringValue("error.invalid"),
+			oteldecode.AttrSeverity:   attribute.StringValue("warning"),
+			oteldecode.AttrRetryable:  attribute.BoolValue(false),
+		}},
+		{"critical", errDatabase, codes.Error, map[attribute.Key]attribute.Value{
+			oteldecode.AttrCode:      attribute.IntValue(1002),
+			oteldecode.AttrSeverity:  attribute.StringValue("critical"),
+			oteldecode.AttrRetryable: attribute.BoolValue(true),
+		}},
+		{"unclassified", errUnknown, codes.Error, nil},
+		{"nil", nil, codes.Unset, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := tracetest.NewSpanRecorder()
+			ctx, span := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test").Start(context.Background(), "request")
+			dec.TranslateContext(ctx, tt.err)
+			span.End()
+
+			s := rec.Ended()[0]
+			if s.Status().Code != tt.wantStatus {
+				t.Fatalf("unexpected status: got=%v want=%v", s.Status().Code, tt.wantStatus)
+			}
+			if tt.err == nil {
+				if len(s.Events()) != 0 {
+					t.Fatalf("unexpected events: %+v", s.Events())
+				}
+				return
+			}
+			if len(s.Events()) != 1 || s.Events()[0].Name != "exception" {
+				t.Fatalf("unexpected events: %+v", s.Events())
+			}
+			attrs := make(map[attribute.Key]attribute.Value)
+			for _, kv := range s.Events()[0].Attributes {
+				attrs[kv.Key] = kv.Value
+			}
+			if got := attrs["exception.message"].AsString(); got != tt.err.Error() {
+				t.Fatalf("unexpected exception message: got=%s want=%s", got, tt.err.Error())
+			}
+			for k, want := range tt.wantAttrs {
+				if got := attrs[k]; got != want {
+					t.Fatalf("unexpected attribute %s: got=%v want=%v", k, got.Emit(), want.Emit())
+				}
+			}
+		})
+	}
+}
+
+func TestRecordSpansWithoutSpan(t *testing.T) {
+	dec := errdecode.New(nil, oteldecode.RecordSpans())
+	if err := dec.TranslateContext(context.Background(), errUnknown); err != errUnknown {
+		t.Fatalf("unexpected error: got=%v want=%v", err, errUnknown)
+	}
+}
@@ -0,0 +1,104 @@
+package errdecode
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MessageOverride is the message of a code in a locale, replacing its
+// translation, see Decoder.OverrideMessage.
+type MessageOverride struct {
+	Code    int
+	Locale  string
+	Message string
+}
+
+type overrideKey struct {
+	code   int
+	locale string // lowercase
+}
+
+// overrides holds the message overrides of a decoder.
+type overrides struct {
+	mu    sync.RWMutex
+	byKey map[overrideKey]MessageOverride
+}
+
+// OverrideMessage replaces the message of a code in the given locale at
+// runtime, on top of the catalogs of the decoder, e.g., when legal requires
+// a different wording in a country:
+//
+//	decoder.OverrideMessage(1001, "de-DE", "Die Zahlung wurde abgelehnt.")
+//
+// The override applies to requests in the locale or in one of its
+// sublocales, e.g., "de-DE-1996", and to requests without a locale if it is
+// the default locale, see Locales. The message is expanded like a
+// translation, see LocaleMessages. It is safe for concurrent use with
+// translations.
+func (d *Decoder) OverrideMessage(code int, locale, msg string) {
+	o := d.overrides
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.byKey == nil {
+		o.byKey = make(map[overrideKey]MessageOverride)
+	}
+	o.byKey[overrideKey{code, strings.ToLower(locale)}] = MessageOverride{code, locale, msg}
+}
+
+// RemoveOverride removes the override of the message of a code in the given
+// locale, if any.
+func (d *Decoder) RemoveOverride(code int, locale string) {
+	o := d.overrides
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.byKey, overrideKey{code, strings.ToLower(locale)})
+}
+
+// Overrides returns the message overrides of the decoder, sorted by code and
+// locale.
+func (d *Decoder) Overrides() []MessageOverride {
+	o := d.overrides
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	mos := make([]MessageOverride, 0, len(o.byKey))
+	for _, mo := range o.byKey {
+		mos = append(mos, mo)
+	}
+	sort.Slice(mos, func(i, j int) bool {
+		if mos[i].Code != mos[j].Code {
+			return mos[i].Code < mos[j].Code
+		}
+		return mos[i].Locale < mos[j].Locale
+	})
+	return mos
+}
+
+// override returns the overridden message of a code for the locale of ctx,
+// along with the locale of the override.
+func (d *Decoder) override(ctx context.Context, code int) (string, string, bool) {
+	o := d.overrides
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if len(o.byKey) == 0 {
+		return "", "", false
+	}
+	locale, ok := LocaleFromContext(ctx)
+	if !ok {
+		if len(d.locales) == 0 {
+			return "", "", false
+		}
+		locale = d.locales[0]
+	}
+	for locale = strings.ToLower(locale); ; {
+		if mo, ok := o.byKey[overrideKey{code, locale}]; ok {
+			return mo.Message, mo.Locale, true
+		}
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			return "", "", false
+		}
+		locale = locale[:i]
+	}
+}
@@ -0,0 +1,60 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestOverrideMessage(t *testing.T) {
+	errDeclined := errors.New("declined")
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "error.declined", Errors: []error{errDeclined}}},
+		errdecode.Locales("de-DE"),
+		errdecode.LocaleMessages("de", errdecode.Messages{"error.declined": "Zahlung abgelehnt."}),
+	)
+	dec.OverrideMessage(1001, "de-DE", "Die Zahlung wurde abgelehnt.")
+	dec.OverrideMessage(1001, "fr", "Paiement refusé.")
+	dec.OverrideMessage(1002, "fr", "Inconnu.")
+
+	tests := []struct {
+		name   string
+		locale string
+		want   string
+	}{
+		{"overridden", "de-DE", "Die Zahlung wurde abgelehnt."},
+		{"sublocale", "de-de-1996", "Die Zahlung wurde abgelehnt."},
+		{"other region", "de-AT", "Zahlung abgelehnt."},
+		{"default locale", "", "Die Zahlung wurde abgelehnt."},
+		{"without catalog", "fr-CA", "Paiement refusé."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.locale != "" {
+				ctx = errdecode.WithLocale(ctx, tt.locale)
+			}
+			if got := dec.TranslateContext(ctx, errDeclined).Error(); got != tt.want {
+				t.Fatalf("unexpected message: got=%s want=%s", got, tt.want)
+			}
+		})
+	}
+
+	want := []errdecode.MessageOverride{
+		{Code: 1001, Locale: "de-DE", Message: "Die Zahlung wurde abgelehnt."},
+		{Code: 1001, Locale: "fr", Message: "Paiement refusé."},
+		{Code: 1002, Locale: "fr", Message: "Inconnu."},
+	}
+	if got := dec.Overrides(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected overrides: got=%+v want=%+v", got, want)
+	}
+
+	dec.RemoveOverride(1001, "DE-de")
+	ctx := errdecode.WithLocale(context.Background(), "de-DE")
+	if got, want := dec.TranslateContext(ctx, errDeclined).Error(), "Zahlung abgelehnt."; got != want {
+		t.Fatalf("unexpected message after removal: got=%s want=%s", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package errdecode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// ErrorPayload is the canonical wire representation of a classified error,
+// shared by servers writing error responses and clients reading them.
+type ErrorPayload struct {
+	// Code is the classification of the error.
+	Code int
+
+	// Message is the translated message.
+	Message string
+
+	// Severity is the severity of the matched rule.
+	Severity Severity
+
+	// Details are the fields of the error, see Fielder.
+	Details map[string]interface{}
+
+	// DocsURL links to documentation for errors of this class.
+	DocsURL string
+
+	// CorrelationID identifies the request the error occurred in, see
+	// WithCorrelationID.
+	CorrelationID string
+
+	// Cause is the message of the underlying error, only set if the decoder
+	// was configured with WithCause or is in debug mode.
+	Cause string
+}
+
+// payloadJSON is the JSON schema of an ErrorPayload.
+type payloadJSON struct {
+	Code          int                    `json:"code"`
+	Message       string                 `json:"message"`
+	Severity      string                 `json:"severity,omitempty"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+	DocsURL       string                 `json:"docs_url,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Cause         string                 `json:"cause,omitempty"`
+}
+
+// MarshalJSON satisfies the json.Marshaler interface. The severity is
+// written by name, see Severity.String.
+func (p ErrorPayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(payloadJSON{
+		Code:          p.Code,
+		Message:       p.Message,
+		Severity:      p.Severity.String(),
+		Details:       p.Details,
+		DocsURL:       p.DocsURL,
+		CorrelationID: p.CorrelationID,
+		Cause:         p.Cause,
+	})
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface. Unknown severities,
+// e.g., introduced by a newer server, are read as SeverityUnspecified.
+func (p *ErrorPayload) UnmarshalJSON(data []byte) error {
+	var pj payloadJSON
+	if err := json.Unmarshal(data, &pj); err != nil {
+		return err
+	}
+	var severity Severity
+	if err := severity.UnmarshalText([]byte(pj.Severity)); err != nil {
+		severity = SeverityUnspecified
+	}
+	*p = ErrorPayload{
+		Code:          pj.Code,
+		Message:       pj.Message,
+		Severity:      severity,
+		Details:       pj.Details,
+		DocsURL:       pj.DocsURL,
+		CorrelationID: pj.CorrelationID,
+		Cause:         pj.Cause,
+	}
+	return nil
+}
+
+// NewErrorPayload returns the payload of a classified error. The DocsURL and
+// CorrelationID are left empty, see Decoder.Payload.
+func NewErrorPayload(ce ClassifiedError) ErrorPayload {
+	p := ErrorPayload{
+		Code:     ce.Code(),
+		Message:  ce.Error(),
+		Severity: ce.Severity(),
+		Details:  ce.Fields(),
+	}
+	var e *matchedError
+	switch t := ce.(type) {
+	case *matchedError:
+		e = t
+	case *matchedFieldError:
+		e = t.matchedError
+	}
+	if e != nil {
+		p.Message = e.message()
+		if (e.withCause || e.debugging()) && e.err != nil {
+			p.Cause = e.err.Error()
+		}
+	}
+	return p
+}
+
+// Err returns the classified error described by the payload. It has no
+// underlying cause, and its message key is the translated message.
+func (p ErrorPayload) Err() ClassifiedError {
+	return &matchedError{code: p.Code, msg: p.Message, key: p.Message, fields: p.Details, severity: p.Severity}
+}
+
+// Payload translates err with ctx and returns its payload, along with the
+// DocsURL of the matched rule and the correlation ID attached to ctx, if any.
+// Unclassified errors have a zero code and an empty message.
+func (d *Decoder) Payload(ctx context.Context, err error) ErrorPayload {
+	var p ErrorPayload
+	var ce ClassifiedError
+	if errors.As(err, &ce) || errors.As(d.TranslateContext(ctx, err), &ce) {
+		p = NewErrorPayload(ce)
+		if rule, ok := d.RuleFor(ce.Code()); ok {
+			p.DocsURL = rule.DocsURL
+		}
+	}
+	p.CorrelationID, _ = CorrelationIDFromContext(ctx)
+	return p
+}
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying the ID correlating errors
+// with the request they occurred in, e.g., a request ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID attached to ctx by
+// WithCorrelationID, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok
+}
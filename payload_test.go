@@ -0,0 +1,100 @@
+package errdecode_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+var errPayloadQuota = errors.New("quota exceeded")
+
+func TestErrorPayloadJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload errdecode.ErrorPayload
+		want    string
+	}{
+		{"minimal", errdecode.ErrorPayload{Code: 1001, Message: "Not found."}, `{"code":1001,"message":"Not found."}`},
+		{"full", errdecode.ErrorPayload{
+			Code:          1002,
+			Message:       "Quota exceeded.",
+			Severity:      errdecode.SeverityWarning,
+			Details:       map[string]interface{}{"limit": float64(10)},
+			DocsURL:       "https://docs.example.com/errors/1002",
+			CorrelationID: "req-42",
+		}, `{"code":1002,"message":"Quota exceeded.","severity":"warning","details":{"limit":10},"docs_url":"https://docs.example.com/errors/1002","correlation_id":"req-42"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Fatalf("unexpected JSON: got=%s want=%s", data, tt.want)
+			}
+
+			var got errdecode.ErrorPayload
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.payload) {
+				t.Fatalf("unexpected payload: got=%+v want=%+v", got, tt.payload)
+			}
+		})
+	}
+}
+
+func TestErrorPayloadUnknownSeverity(t *testing.T) {
+	var p errdecode.ErrorPayload
+	if err := json.Unmarshal([]byte(`{"code":1,"message":"m","severity":"dire"}`), &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Severity != errdecode.SeverityUnspecified {
+		t.Fatalf("unexpected severity: got=%v want=unspecified", p.Severity)
+	}
+}
+
+func TestErrorPayloadErr(t *testing.T) {
+	p := errdecode.ErrorPayload{Code: 1002, Message: "Quota exceeded.", Severity: errdecode.SeverityError, Details: map[string]interface{}{"limit": 10}}
+	ce := p.Err()
+	if ce.Code() != 1002 || ce.Error() != "Quota exceeded." || ce.Severity() != errdecode.SeverityError || ce.Fields()["limit"] != 10 {
+		t.Fatalf("unexpected error: %+v", ce)
+	}
+	if got := errdecode.NewErrorPayload(ce); !reflect.DeepEqual(got, p) {
+		t.Fatalf("unexpected payload: got=%+v want=%+v", got, p)
+	}
+}
+
+func TestDecoderPayload(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{{
+		Code:     1002,
+		Message:  "Quota exceeded.",
+		Errors:   []error{errPayloadQuota},
+		Severity: errdecode.SeverityWarning,
+		DocsURL:  "https://docs.example.com/errors/1002",
+	}})
+	ctx := errdecode.WithCorrelationID(context.Background(), "req-42")
+
+	want := errdecode.ErrorPayload{
+		Code:          1002,
+		Message:       "Quota exceeded.",
+		Severity:      errdecode.SeverityWarning,
+		DocsURL:       "https://docs.example.com/errors/1002",
+		CorrelationID: "req-42",
+	}
+	got := dec.Payload(ctx, errPayloadQuota)
+	got.Details = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected payload: got=%+v want=%+v", got, want)
+	}
+
+	if got := dec.Payload(ctx, errors.New("boom")); got.Code != 0 || got.Message != "" || got.CorrelationID != "req-42" {
+		t.Fatalf("unexpected payload for unclassified error: %+v", got)
+	}
+}
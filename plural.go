@@ -0,0 +1,244 @@
+package errdecode
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Plural categories of CLDR, see PluralCategory.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralCategory returns the CLDR plural category of the count n in the
+// language of locale, e.g., "one" for 1 and "other" for 3 in English, or
+// "few" for 3 in Russian.
+//
+// Categories of the languages with plural forms not shared with English are
+// built in, i.e., Arabic, Czech, French, Hebrew, Polish, Portuguese, Russian,
+// Slovak, Ukrainian and the languages without plural forms, e.g., Japanese.
+// Other languages use the English categories.
+func PluralCategory(locale string, n int64) string {
+	if n < 0 {
+		n = -n
+	}
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	mod10, mod100 := n%10, n%100
+
+	switch lang {
+	case "ja", "zh", "ko", "vi", "th", "id", "ms", "lo", "my", "km":
+		return PluralOther
+	case "fr", "pt":
+		if n <= 1 && !strings.EqualFold(locale, "pt-PT") {
+			return PluralOne
+		}
+	case "ru", "uk", "be":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		}
+		return PluralMany
+	case "pl":
+		switch {
+		case n == 1:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		}
+		return PluralMany
+	case "cs", "sk":
+		switch {
+		case n == 1:
+			return PluralOne
+		case n >= 2 && n <= 4:
+			return PluralFew
+		}
+		return PluralOther
+	case "he":
+		switch n {
+		case 1:
+			return PluralOne
+		case 2:
+			return PluralTwo
+		}
+		return PluralOther
+	case "ar":
+		switch {
+		case n == 0:
+			return PluralZero
+		case n == 1:
+			return PluralOne
+		case n == 2:
+			return PluralTwo
+		case mod100 >= 3 && mod100 <= 10:
+			return PluralFew
+		case mod100 >= 11:
+			return PluralMany
+		}
+		return PluralOther
+	}
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// messageLocale returns the locale of the translation of a message key, used
+// to select plural forms.
+func (d *Decoder) messageLocale(ctx context.Context, key string) string {
+	locale, ok := LocaleFromContext(ctx)
+	if ok && d.catalogs.empty() {
+		return locale
+	}
+	if ok {
+		if _, found := d.localeCatalog(locale)[key]; found {
+			return locale
+		}
+	}
+	if len(d.locales) > 0 {
+		return d.locales[0]
+	}
+	return ""
+}
+
+// expandPlurals replaces plural arguments in msg, in the ICU message format,
+// with the form matching the value of their field:
+//
+//	{count, plural, =0 {No file failed.} one {# file failed.} other {# files failed.}}
+//
+// Forms are selected by exact value, then by plural category in the language
+// of locale, see PluralCategory, then "other". "#" is replaced by the value.
+// Arguments without a matching integer field are left untouched.
+func expandPlurals(msg, locale string, fields map[string]interface{}) string {
+	if len(fields) == 0 || !strings.Contains(msg, "plural") {
+		return msg
+	}
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(msg, '{')
+		if i < 0 {
+			b.WriteString(msg)
+			return b.String()
+		}
+		b.WriteString(msg[:i])
+		msg = msg[i:]
+
+		name, forms, n, ok := parsePlural(msg)
+		if !ok {
+			b.WriteByte('{')
+			msg = msg[1:]
+			continue
+		}
+		count, ok := pluralOperand(fields[name])
+		if !ok {
+			b.WriteString(msg[:n])
+			msg = msg[n:]
+			continue
+		}
+		form, ok := forms["="+strconv.FormatInt(count, 10)]
+		if !ok {
+			if form, ok = forms[PluralCategory(locale, count)]; !ok {
+				form = forms[PluralOther]
+			}
+		}
+		form = strings.ReplaceAll(form, "#", strconv.FormatInt(count, 10))
+		b.WriteString(expandPlurals(form, locale, fields))
+		msg = msg[n:]
+	}
+}
+
+// parsePlural parses the plural argument at the start of s, and returns the
+// name of its field, its forms by selector and its length.
+func parsePlural(s string) (name string, forms map[string]string, n int, ok bool) {
+	end := closingBrace(s)
+	if end < 0 {
+		return "", nil, 0, false
+	}
+	parts := strings.SplitN(s[1:end], ",", 3)
+	if len(parts) != 3 || strings.TrimSpace(parts[1]) != "plural" {
+		return "", nil, 0, false
+	}
+	name = strings.TrimSpace(parts[0])
+
+	forms = make(map[string]string)
+	for rest := strings.TrimSpace(parts[2]); rest != ""; rest = strings.TrimSpace(rest) {
+		i := strings.IndexByte(rest, '{')
+		if i <= 0 {
+			return "", nil, 0, false
+		}
+		j := closingBrace(rest[i:])
+		if j < 0 {
+			return "", nil, 0, false
+		}
+		forms[strings.TrimSpace(rest[:i])] = rest[i+1 : i+j]
+		rest = rest[i+j+1:]
+	}
+	if _, ok := forms[PluralOther]; !ok {
+		return "", nil, 0, false
+	}
+	return name, forms, end + 1, true
+}
+
+// closingBrace returns the index of the brace closing the one at the start of
+// s, or -1.
+func closingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// pluralOperand returns the value of a field as an integer, if it is one.
+// Integral floats, e.g., numbers decoded from JSON, are accepted.
+func pluralOperand(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	case float32:
+		return pluralOperand(float64(v))
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,96 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      int64
+		want   string
+	}{
+		{"en-US", 1, errdecode.PluralOne},
+		{"en-US", 0, errdecode.PluralOther},
+		{"en-US", -1, errdecode.PluralOne},
+		{"fr-FR", 0, errdecode.PluralOne},
+		{"fr-FR", 2, errdecode.PluralOther},
+		{"pt-PT", 0, errdecode.PluralOther},
+		{"ru", 21, errdecode.PluralOne},
+		{"ru", 11, errdecode.PluralMany},
+		{"ru", 23, errdecode.PluralFew},
+		{"ru", 13, errdecode.PluralMany},
+		{"pl", 22, errdecode.PluralFew},
+		{"pl", 21, errdecode.PluralMany},
+		{"cs", 3, errdecode.PluralFew},
+		{"cs", 5, errdecode.PluralOther},
+		{"he", 2, errdecode.PluralTwo},
+		{"ar", 0, errdecode.PluralZero},
+		{"ar", 103, errdecode.PluralFew},
+		{"ar", 111, errdecode.PluralMany},
+		{"ar", 100, errdecode.PluralOther},
+		{"ja_JP", 1, errdecode.PluralOther},
+		{"", 1, errdecode.PluralOne},
+	}
+
+	for _, tt := range tests {
+		if got := errdecode.PluralCategory(tt.locale, tt.n); got != tt.want {
+			t.Fatalf("unexpected category for %s %d: got=%s want=%s", tt.locale, tt.n, got, tt.want)
+		}
+	}
+}
+
+type uploadError struct {
+	fields map[string]interface{}
+}
+
+func (e *uploadError) Error() string                  { return "upload failed" }
+func (e *uploadError) Fields() map[string]interface{} { return e.fields }
+
+func TestPlural(t *testing.T) {
+	const msg = "{count, plural, =0 {No file failed.} one {# file failed in {dir}.} other {# files failed in {dir}.}}"
+	dec := errdecode.New([]errdecode.Rule{{
+		Code:    1001,
+		Message: "error.upload",
+		Match: func(err error) bool {
+			var ue *uploadError
+			return errors.As(err, &ue)
+		},
+	}},
+		errdecode.Locales("en"),
+		errdecode.LocaleMessages("en", errdecode.Messages{"error.upload": msg}),
+		errdecode.LocaleMessages("ru", errdecode.Messages{
+			"error.upload": "{count, plural, one {# файл не загружен.} few {# файла не загружены.} other {# файлов не загружено.}}",
+		}),
+	)
+
+	tests := []struct {
+		name   string
+		locale string
+		fields map[string]interface{}
+		want   string
+	}{
+		{"exact", "en", map[string]interface{}{"count": 0}, "No file failed."},
+		{"one", "en", map[string]interface{}{"count": 1, "dir": "/tmp"}, "1 file failed in /tmp."},
+		{"other", "en", map[string]interface{}{"count": uint8(3), "dir": "/tmp"}, "3 files failed in /tmp."},
+		{"float", "en", map[string]interface{}{"count": 3.0, "dir": "/tmp"}, "3 files failed in /tmp."},
+		{"locale", "ru", map[string]interface{}{"count": 22}, "22 файла не загружены."},
+		{"locale fallback", "ja", map[string]interface{}{"count": 1, "dir": "/tmp"}, "1 file failed in /tmp."},
+		{"missing count", "en", map[string]interface{}{"size": 1}, msg},
+		{"not an integer", "en", map[string]interface{}{"count": 1.5}, msg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := errdecode.WithLocale(context.Background(), tt.locale)
+			err := dec.TranslateContext(ctx, &uploadError{tt.fields})
+			if got := errdecode.MessageOf(err); got != tt.want {
+				t.Fatalf("unexpected message: got=%s want=%s", got, tt.want)
+			}
+		})
+	}
+}
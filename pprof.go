@@ -0,0 +1,31 @@
+package errdecode
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+)
+
+// LabelCode is the pprof label holding the code of the error handled by Do.
+const LabelCode = "errdecode.code"
+
+// Do translates err with ctx, see TranslateContext, and calls f with the
+// translated error in a context labeled with its code, see pprof.Do, so that
+// CPU and heap profiles of error handling paths can be sliced by
+// classification, e.g.:
+//
+//	dec.Do(ctx, err, func(ctx context.Context, err error) {
+//		writeError(ctx, w, err)
+//	})
+//
+// Unclassified errors are labeled "unclassified".
+func (d *Decoder) Do(ctx context.Context, err error, f func(ctx context.Context, translated error)) {
+	translated := d.TranslateContext(ctx, err)
+	label := "unclassified"
+	if ce, ok := translated.(ClassifiedError); ok {
+		label = strconv.Itoa(ce.Code())
+	}
+	pprof.Do(ctx, pprof.Labels(LabelCode, label), func(ctx context.Context) {
+		f(ctx, translated)
+	})
+}
@@ -0,0 +1,40 @@
+package errdecode_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestDo(t *testing.T) {
+	dec := newDecoder()
+
+	tests := []struct {
+		name      string
+		err       error
+		wantLabel string
+	}{
+		{"classified", errClient1, "1001"},
+		{"unclassified", errUnclassified, "unclassified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			dec.Do(context.Background(), tt.err, func(ctx context.Context, err error) {
+				called = true
+				if label, _ := pprof.Label(ctx, errdecode.LabelCode); label != tt.wantLabel {
+					t.Fatalf("unexpected label: got=%s want=%s", label, tt.wantLabel)
+				}
+				if code := errdecode.Code(err); code != errdecode.Code(dec.Translate(tt.err)) {
+					t.Fatalf("unexpected code: got=%d", code)
+				}
+			})
+			if !called {
+				t.Fatalf("unexpected uncalled func")
+			}
+		})
+	}
+}
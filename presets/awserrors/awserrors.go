@@ -0,0 +1,148 @@
+// Package awserrors is a preset classifying the errors returned by AWS SDK
+// for Go v2 clients, by error code and HTTP status:
+//
+//	rules := append(appRules, awserrors.Rules()...)
+//
+// Throttling and server errors are retryable, as they are for the standard
+// retryer of the SDK.
+package awserrors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aws/smithy-go"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeThrottling = 9501 + iota
+	CodeAccessDenied
+	CodeNotFound
+	CodeServiceError
+)
+
+// ThrottleErrorCodes are the error codes of throttled requests, as retried
+// by the SDK.
+var ThrottleErrorCodes = []string{
+	"Throttling",
+	"ThrottlingException",
+	"ThrottledException",
+	"RequestThrottledException",
+	"TooManyRequestsException",
+	"ProvisionedThroughputExceededException",
+	"TransactionInProgressException",
+	"RequestLimitExceeded",
+	"BandwidthLimitExceeded",
+	"LimitExceededException",
+	"RequestThrottled",
+	"SlowDown",
+	"PriorRequestNotComplete",
+	"EC2ThrottledException",
+}
+
+// AccessDeniedErrorCodes are the error codes of requests denied by IAM.
+var AccessDeniedErrorCodes = []string{
+	"AccessDenied",
+	"AccessDeniedException",
+	"UnauthorizedOperation",
+	"AuthorizationError",
+}
+
+// NotFoundErrorCodes are the error codes of requests for missing resources.
+var NotFoundErrorCodes = []string{
+	"ResourceNotFoundException",
+	"NotFound",
+	"NoSuchKey",
+	"NoSuchBucket",
+	"NoSuchEntity",
+}
+
+// ErrorCode returns a matcher reporting whether an error is a
+// smithy.APIError with one of the given error codes.
+func ErrorCode(codes ...string) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var apiErr smithy.APIError
+		if !errors.As(err, &apiErr) {
+			return false
+		}
+		for _, code := range codes {
+			if apiErr.ErrorCode() == code {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HTTPStatus returns a matcher reporting whether an error is the response
+// error of a request that failed with one of the given HTTP statuses. A
+// single digit matches the whole class, e.g., 5 for server errors.
+func HTTPStatus(statuses ...int) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var respErr interface{ HTTPStatusCode() int }
+		if !errors.As(err, &respErr) {
+			return false
+		}
+		code := respErr.HTTPStatusCode()
+		for _, status := range statuses {
+			if code == status || status < 10 && code/100 == status {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isServerFault(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorFault() == smithy.FaultServer
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeThrottling: ThrottleErrorCodes and 429, retryable, 429 Too Many
+//     Requests
+//   - CodeAccessDenied: AccessDeniedErrorCodes and 403, 403 Forbidden
+//   - CodeNotFound: NotFoundErrorCodes and 404, 404 Not Found
+//   - CodeServiceError: server faults and 5xx, retryable, 502 Bad Gateway
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeThrottling,
+			Message:    "Too many requests, please try again later.",
+			Match:      presets.Any(ErrorCode(ThrottleErrorCodes...), HTTPStatus(http.StatusTooManyRequests)),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusTooManyRequests,
+			Tags:       []string{"aws"},
+		},
+		{
+			Code:       CodeAccessDenied,
+			Message:    "You are not allowed to perform this operation.",
+			Match:      presets.Any(ErrorCode(AccessDeniedErrorCodes...), HTTPStatus(http.StatusForbidden)),
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusForbidden,
+			Tags:       []string{"aws"},
+		},
+		{
+			Code:       CodeNotFound,
+			Message:    "The requested resource was not found.",
+			Match:      presets.Any(ErrorCode(NotFoundErrorCodes...), HTTPStatus(http.StatusNotFound)),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusNotFound,
+			Tags:       []string{"aws"},
+		},
+		{
+			Code:       CodeServiceError,
+			Message:    "An upstream service failed, please try again.",
+			Match:      presets.Any(isServerFault, HTTPStatus(5)),
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"aws"},
+		},
+	}, options...)
+}
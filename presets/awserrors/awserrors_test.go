@@ -0,0 +1,59 @@
+package awserrors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/awserrors"
+)
+
+// operationError mimics the errors returned by SDK clients.
+func operationError(status int, apiErr error) error {
+	return &smithy.OperationError{
+		ServiceID:     "S3",
+		OperationName: "GetObject",
+		Err: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+			Err:      apiErr,
+		},
+	}
+}
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(awserrors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"throttling", operationError(400, &smithy.GenericAPIError{Code: "ThrottlingException"}), awserrors.CodeThrottling, true},
+		{"too many requests", operationError(429, errors.New("slow down")), awserrors.CodeThrottling, true},
+		{"access denied", fmt.Errorf("get object: %w", operationError(403, &smithy.GenericAPIError{Code: "AccessDenied"})), awserrors.CodeAccessDenied, false},
+		{"not found", operationError(404, &smithy.GenericAPIError{Code: "NoSuchKey"}), awserrors.CodeNotFound, false},
+		{"not found code", &smithy.GenericAPIError{Code: "ResourceNotFoundException"}, awserrors.CodeNotFound, false},
+		{"server fault", &smithy.GenericAPIError{Code: "InternalError", Fault: smithy.FaultServer}, awserrors.CodeServiceError, true},
+		{"server error", operationError(503, errors.New("unavailable")), awserrors.CodeServiceError, true},
+		{"client fault", operationError(400, &smithy.GenericAPIError{Code: "ValidationException", Fault: smithy.FaultClient}), 0, false},
+		{"other", errors.New("AccessDenied"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
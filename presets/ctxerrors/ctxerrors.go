@@ -0,0 +1,63 @@
+// Package ctxerrors is a preset classifying context.Canceled and
+// context.DeadlineExceeded:
+//
+//	rules := append(appRules, ctxerrors.Rules()...)
+//
+// Matchers of the errors are registered for rule catalogs under the IDs
+// "context.canceled" and "context.deadline_exceeded".
+package ctxerrors
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeCanceled = 9151 + iota
+	CodeDeadlineExceeded
+)
+
+// StatusClientClosedRequest is the non-standard HTTP status, introduced by
+// nginx, of requests canceled by clients.
+const StatusClientClosedRequest = 499
+
+func init() {
+	errdecode.RegisterMatcher("context.canceled", presets.Is(context.Canceled))
+	errdecode.RegisterMatcher("context.deadline_exceeded", presets.Is(context.DeadlineExceeded))
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeCanceled: context.Canceled, 499 Client Closed Request, gRPC
+//     Canceled
+//   - CodeDeadlineExceeded: context.DeadlineExceeded, retryable, 504 Gateway
+//     Timeout, gRPC DeadlineExceeded
+//
+// Cancellation is not retryable, since the client gave up on the request.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeCanceled,
+			Message:    "The request was canceled.",
+			Match:      presets.Is(context.Canceled),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: StatusClientClosedRequest,
+			GRPCCode:   1, // Canceled
+			Tags:       []string{"context"},
+		},
+		{
+			Code:       CodeDeadlineExceeded,
+			Message:    "The request timed out, please try again.",
+			Match:      presets.Is(context.DeadlineExceeded),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusGatewayTimeout,
+			GRPCCode:   4, // DeadlineExceeded
+			Tags:       []string{"context"},
+		},
+	}, options...)
+}
@@ -0,0 +1,44 @@
+package ctxerrors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/ctxerrors"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(ctxerrors.Rules())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	timeout, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"canceled", fmt.Errorf("fetch: %w", ctx.Err()), ctxerrors.CodeCanceled, false},
+		{"deadline exceeded", timeout.Err(), ctxerrors.CodeDeadlineExceeded, true},
+		{"other", errors.New("canceled"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package fserrors is a preset classifying the errors of packages io/fs and
+// os, for file-backed services:
+//
+//	rules := append(appRules, fserrors.Rules()...)
+//
+// Matchers of the errors are registered for rule catalogs under the IDs
+// "fs.not_exist", "fs.permission", "fs.exist" and "fs.deadline_exceeded".
+package fserrors
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeNotFound = 9251 + iota
+	CodeForbidden
+	CodeConflict
+	CodeDeadlineExceeded
+	CodeFileError
+)
+
+func init() {
+	errdecode.RegisterMatcher("fs.not_exist", presets.Is(fs.ErrNotExist))
+	errdecode.RegisterMatcher("fs.permission", presets.Is(fs.ErrPermission))
+	errdecode.RegisterMatcher("fs.exist", presets.Is(fs.ErrExist))
+	errdecode.RegisterMatcher("fs.deadline_exceeded", presets.Is(os.ErrDeadlineExceeded))
+}
+
+func isPathError(err error) bool {
+	var pathErr *fs.PathError
+	return errors.As(err, &pathErr)
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeNotFound: fs.ErrNotExist, 404 Not Found
+//   - CodeForbidden: fs.ErrPermission, 403 Forbidden
+//   - CodeConflict: fs.ErrExist, 409 Conflict
+//   - CodeDeadlineExceeded: os.ErrDeadlineExceeded, retryable, 504 Gateway
+//     Timeout
+//   - CodeFileError: other *fs.PathError, 500 Internal Server Error
+//
+// The path of a *fs.PathError is never part of the messages, so file system
+// layouts do not leak to clients.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeNotFound,
+			Message:    "The requested file was not found.",
+			Match:      presets.Is(fs.ErrNotExist),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusNotFound,
+			Tags:       []string{"fs"},
+		},
+		{
+			Code:       CodeForbidden,
+			Message:    "Access to the requested file is forbidden.",
+			Match:      presets.Is(fs.ErrPermission),
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusForbidden,
+			Tags:       []string{"fs"},
+		},
+		{
+			Code:       CodeConflict,
+			Message:    "The file already exists.",
+			Match:      presets.Is(fs.ErrExist),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"fs"},
+		},
+		{
+			Code:       CodeDeadlineExceeded,
+			Message:    "The file operation timed out, please try again.",
+			Match:      presets.Is(os.ErrDeadlineExceeded),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusGatewayTimeout,
+			Tags:       []string{"fs"},
+		},
+		{
+			Code:       CodeFileError,
+			Message:    "The file operation failed.",
+			Match:      isPathError,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusInternalServerError,
+			Tags:       []string{"fs"},
+		},
+	}, options...)
+}
@@ -0,0 +1,48 @@
+package fserrors_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/fserrors"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(fserrors.Rules())
+
+	dir := t.TempDir()
+	_, errNotExist := os.Open(filepath.Join(dir, "missing"))
+	_, errExist := os.OpenFile(dir, os.O_CREATE|os.O_EXCL, 0o600)
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"not exist", fmt.Errorf("load config: %w", errNotExist), fserrors.CodeNotFound, false},
+		{"permission", &fs.PathError{Op: "open", Path: "/root", Err: fs.ErrPermission}, fserrors.CodeForbidden, false},
+		{"exist", errExist, fserrors.CodeConflict, false},
+		{"deadline exceeded", &fs.PathError{Op: "read", Path: "pipe", Err: os.ErrDeadlineExceeded}, fserrors.CodeDeadlineExceeded, true},
+		{"path error", &fs.PathError{Op: "read", Path: "file", Err: errors.New("is a directory")}, fserrors.CodeFileError, false},
+		{"other", errors.New("file not found"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
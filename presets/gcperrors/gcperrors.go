@@ -0,0 +1,125 @@
+// Package gcperrors is a preset classifying the errors returned by Google
+// Cloud clients, both REST clients reporting a *googleapi.Error and gRPC
+// clients reporting a status:
+//
+//	rules := append(appRules, gcperrors.Rules()...)
+//
+// Classified errors present a message of their own, so that details of the
+// Google Cloud plumbing, e.g., project or bucket names, do not reach clients.
+package gcperrors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Codes of the preset rules.
+const (
+	CodeQuotaExceeded = 9551 + iota
+	CodePermissionDenied
+	CodeNotFound
+)
+
+// QuotaReasons are the reasons of *googleapi.Error items reporting exceeded
+// quotas or rate limits, which may come with a 403 Forbidden status.
+var QuotaReasons = []string{
+	"rateLimitExceeded",
+	"userRateLimitExceeded",
+	"quotaExceeded",
+	"dailyLimitExceeded",
+}
+
+// HTTPCode returns a matcher reporting whether an error is a *googleapi.Error
+// with one of the given HTTP status codes.
+func HTTPCode(codes ...int) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) {
+			return false
+		}
+		for _, code := range codes {
+			if apiErr.Code == code {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Reason returns a matcher reporting whether an error is a *googleapi.Error
+// with an item of one of the given reasons.
+func Reason(reasons ...string) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) {
+			return false
+		}
+		for _, item := range apiErr.Errors {
+			for _, reason := range reasons {
+				if item.Reason == reason {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// GRPCCode returns a matcher reporting whether an error carries a gRPC status
+// with one of the given codes.
+func GRPCCode(cs ...codes.Code) errdecode.MatcherFunc {
+	return func(err error) bool {
+		st, ok := status.FromError(err)
+		if !ok {
+			return false
+		}
+		for _, c := range cs {
+			if st.Code() == c {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeQuotaExceeded: 429, QuotaReasons and ResourceExhausted, retryable,
+//     429 Too Many Requests
+//   - CodePermissionDenied: 403 and PermissionDenied, 403 Forbidden
+//   - CodeNotFound: 404 and NotFound, 404 Not Found
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeQuotaExceeded,
+			Message:    "Too many requests, please try again later.",
+			Match:      presets.Any(HTTPCode(http.StatusTooManyRequests), Reason(QuotaReasons...), GRPCCode(codes.ResourceExhausted)),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusTooManyRequests,
+			Tags:       []string{"gcp"},
+		},
+		{
+			Code:       CodePermissionDenied,
+			Message:    "You are not allowed to perform this operation.",
+			Match:      presets.Any(HTTPCode(http.StatusForbidden), GRPCCode(codes.PermissionDenied)),
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusForbidden,
+			Tags:       []string{"gcp"},
+		},
+		{
+			Code:       CodeNotFound,
+			Message:    "The requested resource was not found.",
+			Match:      presets.Any(HTTPCode(http.StatusNotFound), GRPCCode(codes.NotFound)),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusNotFound,
+			Tags:       []string{"gcp"},
+		},
+	}, options...)
+}
@@ -0,0 +1,46 @@
+package gcperrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/gcperrors"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(gcperrors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"rate limited", &googleapi.Error{Code: 429}, gcperrors.CodeQuotaExceeded, true},
+		{"quota reason", &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}}}, gcperrors.CodeQuotaExceeded, true},
+		{"resource exhausted", status.Error(codes.ResourceExhausted, "quota exceeded for project acme-prod"), gcperrors.CodeQuotaExceeded, true},
+		{"forbidden", fmt.Errorf("upload: %w", &googleapi.Error{Code: 403, Message: "svc@acme.iam lacks storage.objects.create"}), gcperrors.CodePermissionDenied, false},
+		{"permission denied", status.Error(codes.PermissionDenied, "denied"), gcperrors.CodePermissionDenied, false},
+		{"not found", &googleapi.Error{Code: 404}, gcperrors.CodeNotFound, false},
+		{"grpc not found", status.Error(codes.NotFound, "no such topic"), gcperrors.CodeNotFound, false},
+		{"other", &googleapi.Error{Code: 400}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+module github.com/iamrgon/errdecode/presets/gcperrors
+
+go 1.21
+
+require (
+	github.com/iamrgon/errdecode v0.1.0
+	google.golang.org/api v0.200.0
+	google.golang.org/grpc v1.67.1
+)
+
+require (
+	cloud.google.com/go v0.115.1 // indirect
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	golang.org/x/net v0.30.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+	golang.org/x/text v0.19.0 // indirect
+	google.golang.org/genproto v0.0.0-20241007155032-5fefd90f89a9 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241007155032-5fefd90f89a9 // indirect
+	google.golang.org/protobuf v1.35.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
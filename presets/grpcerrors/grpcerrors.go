@@ -0,0 +1,95 @@
+// Package grpcerrors is a preset classifying the errors returned by gRPC
+// client calls, by status code:
+//
+//	rules := append(appRules, grpcerrors.Rules()...)
+//
+// Classified errors present a message of their own rather than the message
+// of the upstream status, which remains available in their wrap chain, e.g.,
+// for logging with status.FromError.
+package grpcerrors
+
+import (
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Codes of the preset rules.
+const (
+	CodeNotFound = 9451 + iota
+	CodeUnavailable
+	CodeDeadlineExceeded
+	CodePermissionDenied
+)
+
+// Code returns a matcher reporting whether an error carries a gRPC status
+// with one of the given codes, e.g., returned by a client call.
+func Code(cs ...codes.Code) errdecode.MatcherFunc {
+	return func(err error) bool {
+		st, ok := status.FromError(err)
+		if !ok {
+			return false
+		}
+		for _, c := range cs {
+			if st.Code() == c {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeNotFound: NotFound, 404 Not Found
+//   - CodeUnavailable: Unavailable, retryable, 503 Service Unavailable
+//   - CodeDeadlineExceeded: DeadlineExceeded, retryable, 504 Gateway Timeout
+//   - CodePermissionDenied: PermissionDenied, 403 Forbidden
+//
+// The rules keep the gRPC code of the upstream status, see
+// errdecode.Rule.GRPCCode.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeNotFound,
+			Message:    "The requested resource was not found.",
+			Match:      Code(codes.NotFound),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusNotFound,
+			GRPCCode:   int(codes.NotFound),
+			Tags:       []string{"grpc"},
+		},
+		{
+			Code:       CodeUnavailable,
+			Message:    "An upstream service is unavailable, please try again later.",
+			Match:      Code(codes.Unavailable),
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusServiceUnavailable,
+			GRPCCode:   int(codes.Unavailable),
+			Tags:       []string{"grpc"},
+		},
+		{
+			Code:       CodeDeadlineExceeded,
+			Message:    "An upstream service timed out, please try again.",
+			Match:      Code(codes.DeadlineExceeded),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusGatewayTimeout,
+			GRPCCode:   int(codes.DeadlineExceeded),
+			Tags:       []string{"grpc"},
+		},
+		{
+			Code:       CodePermissionDenied,
+			Message:    "You are not allowed to perform this operation.",
+			Match:      Code(codes.PermissionDenied),
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusForbidden,
+			GRPCCode:   int(codes.PermissionDenied),
+			Tags:       []string{"grpc"},
+		},
+	}, options...)
+}
@@ -0,0 +1,49 @@
+package grpcerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/grpcerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(grpcerrors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"not found", status.Error(codes.NotFound, "user 42 not found in shard 7"), grpcerrors.CodeNotFound, false},
+		{"unavailable", fmt.Errorf("get user: %w", status.Error(codes.Unavailable, "connection refused")), grpcerrors.CodeUnavailable, true},
+		{"deadline exceeded", status.Error(codes.DeadlineExceeded, "context deadline exceeded"), grpcerrors.CodeDeadlineExceeded, true},
+		{"permission denied", status.Error(codes.PermissionDenied, "missing scope users.read"), grpcerrors.CodePermissionDenied, false},
+		{"other code", status.Error(codes.Internal, "boom"), 0, false},
+		{"other", errors.New("not found"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if !errors.As(err, &ce) {
+				return
+			}
+			if ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+			if st, _ := status.FromError(ce.Unwrap()); ce.Error() == st.Message() {
+				t.Fatalf("unexpected upstream message: %s", ce.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package httperrors is a preset classifying the failures of outbound HTTP
+// requests, reported by errdecode.FromHTTPStatus, by status range:
+//
+//	rules := append(appRules, httperrors.Rules()...)
+//
+//	if err := errdecode.FromHTTPStatus(resp); err != nil {
+//		return decoder.Translate(err)
+//	}
+//
+// The HTTP statuses of the preset rules describe the failure to the clients
+// of the application rather than forwarding the status of the upstream
+// service, e.g., a rate limited request is a 503 Service Unavailable.
+package httperrors
+
+import (
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeUnauthorized = 9051 + iota
+	CodeNotFound
+	CodeRateLimited
+	CodeUnavailable
+	CodeClientError
+	CodeServerError
+)
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeUnauthorized: 401 and 403, 502 Bad Gateway
+//   - CodeNotFound: 404 and 410, 404 Not Found
+//   - CodeRateLimited: 429, retryable, 503 Service Unavailable
+//   - CodeUnavailable: 502 to 504, retryable, 503 Service Unavailable
+//   - CodeClientError: other 4xx, 500 Internal Server Error
+//   - CodeServerError: other 5xx, 502 Bad Gateway
+//
+// Rules are ordered from the most specific to the most generic status range.
+// Rejected credentials denote a misconfiguration of the application, so they
+// are not reported as authentication errors to clients.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeUnauthorized,
+			Message:    "An upstream service rejected the request.",
+			Match:      presets.Any(errdecode.StatusRange(401, 401), errdecode.StatusRange(403, 403)),
+			Severity:   errdecode.SeverityCritical,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"http"},
+		},
+		{
+			Code:       CodeNotFound,
+			Message:    "The requested resource could not be found.",
+			Match:      presets.Any(errdecode.StatusRange(404, 404), errdecode.StatusRange(410, 410)),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusNotFound,
+			Tags:       []string{"http"},
+		},
+		{
+			Code:       CodeRateLimited,
+			Message:    "An upstream service is busy, please try again later.",
+			Match:      errdecode.StatusRange(429, 429),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"http"},
+		},
+		{
+			Code:       CodeUnavailable,
+			Message:    "An upstream service is unavailable, please try again later.",
+			Match:      errdecode.StatusRange(502, 504),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"http"},
+		},
+		{
+			Code:       CodeClientError,
+			Message:    "An upstream service rejected the request.",
+			Match:      errdecode.StatusRange(400, 499),
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusInternalServerError,
+			Tags:       []string{"http"},
+		},
+		{
+			Code:       CodeServerError,
+			Message:    "An upstream service failed.",
+			Match:      errdecode.StatusRange(500, 599),
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"http"},
+		},
+	}, options...)
+}
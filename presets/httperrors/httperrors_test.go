@@ -0,0 +1,50 @@
+package httperrors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/httperrors"
+)
+
+func response(status int) error {
+	return errdecode.FromHTTPStatus(&http.Response{StatusCode: status, Status: fmt.Sprintf("%d %s", status, http.StatusText(status))})
+}
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(httperrors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"unauthorized", response(http.StatusUnauthorized), httperrors.CodeUnauthorized, false},
+		{"forbidden", response(http.StatusForbidden), httperrors.CodeUnauthorized, false},
+		{"not found", fmt.Errorf("get user: %w", response(http.StatusNotFound)), httperrors.CodeNotFound, false},
+		{"gone", response(http.StatusGone), httperrors.CodeNotFound, false},
+		{"rate limited", response(http.StatusTooManyRequests), httperrors.CodeRateLimited, true},
+		{"unavailable", response(http.StatusServiceUnavailable), httperrors.CodeUnavailable, true},
+		{"gateway timeout", response(http.StatusGatewayTimeout), httperrors.CodeUnavailable, true},
+		{"bad request", response(http.StatusBadRequest), httperrors.CodeClientError, false},
+		{"internal server error", response(http.StatusInternalServerError), httperrors.CodeServerError, false},
+		{"other", errors.New("503 Service Unavailable"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Package ioerrors is a preset classifying io.EOF, io.ErrUnexpectedEOF and
+// io.ErrClosedPipe, so that streaming and upload handlers do not report
+// "unexpected EOF" to clients:
+//
+//	rules := append(appRules, ioerrors.Rules()...)
+//
+// Matchers of the errors are registered for rule catalogs under the IDs
+// "io.eof", "io.unexpected_eof" and "io.closed_pipe".
+package ioerrors
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeEmptyInput = 9301 + iota
+	CodeTruncatedInput
+	CodeConnectionDropped
+)
+
+func init() {
+	errdecode.RegisterMatcher("io.eof", presets.Is(io.EOF))
+	errdecode.RegisterMatcher("io.unexpected_eof", presets.Is(io.ErrUnexpectedEOF))
+	errdecode.RegisterMatcher("io.closed_pipe", presets.Is(io.ErrClosedPipe))
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeEmptyInput: io.EOF, 400 Bad Request
+//   - CodeTruncatedInput: io.ErrUnexpectedEOF, 400 Bad Request
+//   - CodeConnectionDropped: io.ErrClosedPipe, retryable, 502 Bad Gateway
+//
+// io.EOF is classified as empty input since it is typically returned when
+// decoding an empty request body, e.g., by json.Decoder.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeEmptyInput,
+			Message:    "The request body is empty.",
+			Match:      presets.Is(io.EOF),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusBadRequest,
+			Tags:       []string{"io"},
+		},
+		{
+			Code:       CodeTruncatedInput,
+			Message:    "The request body is incomplete.",
+			Match:      presets.Is(io.ErrUnexpectedEOF),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusBadRequest,
+			Tags:       []string{"io"},
+		},
+		{
+			Code:       CodeConnectionDropped,
+			Message:    "The connection was closed unexpectedly, please try again.",
+			Match:      presets.Is(io.ErrClosedPipe),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"io"},
+		},
+	}, options...)
+}
@@ -0,0 +1,46 @@
+package ioerrors_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/ioerrors"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(ioerrors.Rules())
+
+	var v interface{}
+	errEmpty := json.NewDecoder(strings.NewReader("")).Decode(&v)
+	errTruncated := json.NewDecoder(strings.NewReader(`{"name": "gop`)).Decode(&v)
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"empty body", fmt.Errorf("decode order: %w", errEmpty), ioerrors.CodeEmptyInput, false},
+		{"truncated body", errTruncated, ioerrors.CodeTruncatedInput, false},
+		{"closed pipe", fmt.Errorf("write: %w", io.ErrClosedPipe), ioerrors.CodeConnectionDropped, true},
+		{"other", errors.New("EOF"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package jsonerrors is a preset classifying the errors of decoding JSON
+// request bodies with encoding/json:
+//
+//	rules := append(appRules, jsonerrors.Rules()...)
+//
+// Errors returned by Decode, or wrapped with Wrap, carry the location of the
+// invalid input as fields, e.g., the field of a *json.UnmarshalTypeError.
+package jsonerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeInvalidBody = 9401 + iota
+	CodeInvalidField
+)
+
+// Decode decodes the JSON value read from r into v. Decoding errors are
+// wrapped with Wrap.
+func Decode(r io.Reader, v interface{}) error {
+	return Wrap(json.NewDecoder(r).Decode(v))
+}
+
+// Wrap returns err annotated with the location of the invalid input, as
+// fields of the classified error, see errdecode.Fielder:
+//
+//   - "offset": the offset of a *json.SyntaxError
+//   - "field", "expected" and "value": the dotted path of the field, the Go
+//     type and the JSON value of a *json.UnmarshalTypeError
+//
+// Other errors are returned as-is.
+func Wrap(err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &decodeError{err, map[string]interface{}{"offset": syntaxErr.Offset}}
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		fields := map[string]interface{}{"value": typeErr.Value, "expected": typeErr.Type.String()}
+		if typeErr.Field != "" {
+			fields["field"] = typeErr.Field
+		}
+		return &decodeError{err, fields}
+	}
+	return err
+}
+
+type decodeError struct {
+	err    error
+	fields map[string]interface{}
+}
+
+func (e *decodeError) Error() string                  { return e.err.Error() }
+func (e *decodeError) Unwrap() error                  { return e.err }
+func (e *decodeError) Fields() map[string]interface{} { return e.fields }
+
+func isSyntaxError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	return errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func isTypeError(err error) bool {
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &typeErr)
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeInvalidBody: *json.SyntaxError and io.ErrUnexpectedEOF, 400 Bad
+//     Request
+//   - CodeInvalidField: *json.UnmarshalTypeError, 400 Bad Request
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeInvalidBody,
+			Message:    "The request body is not valid JSON.",
+			Match:      isSyntaxError,
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusBadRequest,
+			Tags:       []string{"json"},
+		},
+		{
+			Code:       CodeInvalidField,
+			Message:    "The request body has a field of the wrong type.",
+			Match:      isTypeError,
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusBadRequest,
+			Tags:       []string{"json"},
+		},
+	}, options...)
+}
@@ -0,0 +1,68 @@
+package jsonerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/jsonerrors"
+)
+
+type order struct {
+	Customer struct {
+		Age int `json:"age"`
+	} `json:"customer"`
+}
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(jsonerrors.Rules())
+
+	tests := []struct {
+		name       string
+		body       string
+		wantCode   int
+		wantFields map[string]interface{}
+	}{
+		{"valid", `{"customer": {"age": 42}}`, 0, nil},
+		{"syntax", `{"customer": }`, jsonerrors.CodeInvalidBody, map[string]interface{}{"offset": int64(14)}},
+		{"truncated", `{"customer": {"age"`, jsonerrors.CodeInvalidBody, nil},
+		{"type", `{"customer": {"age": "42"}}`, jsonerrors.CodeInvalidField, map[string]interface{}{"field": "customer.age", "expected": "int", "value": "string"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var o order
+			err := jsonerrors.Decode(strings.NewReader(tt.body), &o)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var ce errdecode.ClassifiedError
+			if !errors.As(dec.Translate(fmt.Errorf("decode order: %w", err)), &ce) {
+				t.Fatalf("unexpected unclassified error: %v", err)
+			}
+			if ce.Code() != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", ce.Code(), tt.wantCode)
+			}
+			if !reflect.DeepEqual(ce.Fields(), tt.wantFields) {
+				t.Fatalf("unexpected fields: got=%v want=%v", ce.Fields(), tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestWrapOther(t *testing.T) {
+	err := errors.New("boom")
+	if got := jsonerrors.Wrap(err); got != err {
+		t.Fatalf("unexpected error: got=%v want=%v", got, err)
+	}
+	if jsonerrors.Wrap(nil) != nil {
+		t.Fatalf("expected a nil error")
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/iamrgon/errdecode/presets/jwterrors
+
+go 1.21
+
+require (
+	github.com/golang-jwt/jwt/v4 v4.5.1
+	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/iamrgon/errdecode v0.1.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
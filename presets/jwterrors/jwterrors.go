@@ -0,0 +1,74 @@
+// Package jwterrors is a preset classifying the validation errors of
+// github.com/golang-jwt/jwt into distinct codes, so that clients can tell an
+// expired token, to refresh, from a forged one, requiring to sign in again:
+//
+//	rules := append(appRules, jwterrors.Rules()...)
+//
+// Errors of both versions 4 and 5 of the module are supported.
+package jwterrors
+
+import (
+	"net/http"
+
+	jwtv4 "github.com/golang-jwt/jwt/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeExpired = 9901 + iota
+	CodeNotValidYet
+	CodeSignatureInvalid
+	CodeMalformed
+)
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeExpired: ErrTokenExpired, 401 Unauthorized
+//   - CodeNotValidYet: ErrTokenNotValidYet, retryable, 401 Unauthorized
+//   - CodeSignatureInvalid: ErrTokenSignatureInvalid, 401 Unauthorized
+//   - CodeMalformed: ErrTokenMalformed, 401 Unauthorized
+//
+// Tokens not valid yet are retryable, since they are typically caused by a
+// clock skew between the issuer and the application. Version 4 reports every
+// failed validation of a token, so an invalid signature or a malformed token
+// takes precedence over its expiration.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeSignatureInvalid,
+			Message:    "Your token is invalid, please sign in again.",
+			Match:      presets.Is(jwt.ErrTokenSignatureInvalid, jwtv4.ErrTokenSignatureInvalid),
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusUnauthorized,
+			Tags:       []string{"jwt", "auth"},
+		},
+		{
+			Code:       CodeMalformed,
+			Message:    "Your token is invalid, please sign in again.",
+			Match:      presets.Is(jwt.ErrTokenMalformed, jwtv4.ErrTokenMalformed),
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusUnauthorized,
+			Tags:       []string{"jwt", "auth"},
+		},
+		{
+			Code:       CodeExpired,
+			Message:    "Your token has expired, please refresh it.",
+			Match:      presets.Is(jwt.ErrTokenExpired, jwtv4.ErrTokenExpired),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusUnauthorized,
+			Tags:       []string{"jwt", "auth"},
+		},
+		{
+			Code:       CodeNotValidYet,
+			Message:    "Your token is not valid yet, please try again.",
+			Match:      presets.Is(jwt.ErrTokenNotValidYet, jwtv4.ErrTokenNotValidYet),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusUnauthorized,
+			Tags:       []string{"jwt", "auth"},
+		},
+	}, options...)
+}
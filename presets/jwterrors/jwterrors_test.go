@@ -0,0 +1,72 @@
+package jwterrors_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	jwtv4 "github.com/golang-jwt/jwt/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/jwterrors"
+)
+
+var key = []byte("secret")
+
+func sign(t *testing.T, claims jwt.Claims, key []byte) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return token
+}
+
+func parse(token string) error {
+	_, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return key, nil })
+	return err
+}
+
+func parseV4(token string) error {
+	_, err := jwtv4.Parse(token, func(*jwtv4.Token) (interface{}, error) { return key, nil })
+	return err
+}
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(jwterrors.Rules())
+
+	now := time.Now()
+	expired := sign(t, jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(now.Add(-time.Hour))}, key)
+	notYet := sign(t, jwt.RegisteredClaims{NotBefore: jwt.NewNumericDate(now.Add(time.Hour))}, key)
+	forged := sign(t, jwt.RegisteredClaims{}, []byte("forged"))
+	forgedExpired := sign(t, jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(now.Add(-time.Hour))}, []byte("forged"))
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"expired", parse(expired), jwterrors.CodeExpired, false},
+		{"not valid yet", parse(notYet), jwterrors.CodeNotValidYet, true},
+		{"signature invalid", parse(forged), jwterrors.CodeSignatureInvalid, false},
+		{"malformed", parse("not.a.token"), jwterrors.CodeMalformed, false},
+		{"v4 expired", parseV4(expired), jwterrors.CodeExpired, false},
+		{"v4 forged and expired", parseV4(forgedExpired), jwterrors.CodeSignatureInvalid, false},
+		{"v4 malformed", parseV4("token"), jwterrors.CodeMalformed, false},
+		{"other", errors.New("token is expired"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Package k8serrors is a preset classifying the errors returned by the
+// Kubernetes API server, using the helpers of k8s.io/apimachinery:
+//
+//	rules := append(appRules, k8serrors.Rules()...)
+package k8serrors
+
+import (
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Codes of the preset rules.
+const (
+	CodeNotFound = 9601 + iota
+	CodeConflict
+	CodeAlreadyExists
+	CodeForbidden
+)
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeNotFound: apierrors.IsNotFound, 404 Not Found
+//   - CodeConflict: apierrors.IsConflict, retryable, 409 Conflict
+//   - CodeAlreadyExists: apierrors.IsAlreadyExists, 409 Conflict
+//   - CodeForbidden: apierrors.IsForbidden, 403 Forbidden
+//
+// Conflicts are retryable since they report a stale resource version, i.e.,
+// the update may succeed once the resource is fetched again.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeNotFound,
+			Message:    "The requested resource was not found.",
+			Match:      apierrors.IsNotFound,
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusNotFound,
+			Tags:       []string{"kubernetes"},
+		},
+		{
+			Code:       CodeConflict,
+			Message:    "The resource was modified concurrently, please try again.",
+			Match:      apierrors.IsConflict,
+			Retryable:  true,
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"kubernetes"},
+		},
+		{
+			Code:       CodeAlreadyExists,
+			Message:    "The resource already exists.",
+			Match:      apierrors.IsAlreadyExists,
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"kubernetes"},
+		},
+		{
+			Code:       CodeForbidden,
+			Message:    "You are not allowed to perform this operation.",
+			Match:      apierrors.IsForbidden,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusForbidden,
+			Tags:       []string{"kubernetes"},
+		},
+	}, options...)
+}
@@ -0,0 +1,44 @@
+package k8serrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/k8serrors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(k8serrors.Rules())
+	pods := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"not found", fmt.Errorf("get pod: %w", apierrors.NewNotFound(pods, "web-0")), k8serrors.CodeNotFound, false},
+		{"conflict", apierrors.NewConflict(pods, "web-0", errors.New("the object has been modified")), k8serrors.CodeConflict, true},
+		{"already exists", apierrors.NewAlreadyExists(pods, "web-0"), k8serrors.CodeAlreadyExists, false},
+		{"forbidden", apierrors.NewForbidden(pods, "web-0", errors.New("RBAC: access denied")), k8serrors.CodeForbidden, false},
+		{"other status", apierrors.NewBadRequest("invalid selector"), 0, false},
+		{"other", errors.New("not found"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Package kafkaerrors is a preset classifying the errors returned by Kafka
+// clients, by Kafka protocol error code, into retryable and fatal classes:
+//
+//	rules := append(appRules, kafkaerrors.Rules()...)
+//
+// Errors of both github.com/IBM/sarama, i.e., sarama.KError, and
+// github.com/twmb/franz-go, i.e., *kerr.Error, are supported.
+package kafkaerrors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/IBM/sarama"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"github.com/twmb/franz-go/pkg/kerr"
+)
+
+// Codes of the preset rules.
+const (
+	CodeUnknownTopic = 9751 + iota
+	CodeNotLeader
+	CodeTimeout
+	CodeMessageTooLarge
+)
+
+// Kafka protocol error codes of the preset rules, see
+// https://kafka.apache.org/protocol#protocol_error_codes.
+const (
+	UnknownTopicOrPartition int16 = 3
+	NotLeaderForPartition   int16 = 6
+	RequestTimedOut         int16 = 7
+	MessageTooLarge         int16 = 10
+)
+
+// ErrorCode returns the Kafka protocol error code of the first Kafka error in
+// the wrap chain of err, if any.
+func ErrorCode(err error) (int16, bool) {
+	var saramaErr sarama.KError
+	if errors.As(err, &saramaErr) {
+		return int16(saramaErr), true
+	}
+	var franzErr *kerr.Error
+	if errors.As(err, &franzErr) {
+		return franzErr.Code, true
+	}
+	return 0, false
+}
+
+// Code returns a matcher reporting whether an error is a Kafka error with one
+// of the given protocol error codes.
+func Code(codes ...int16) errdecode.MatcherFunc {
+	return func(err error) bool {
+		code, ok := ErrorCode(err)
+		if !ok {
+			return false
+		}
+		for _, c := range codes {
+			if code == c {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeUnknownTopic: UnknownTopicOrPartition, 500 Internal Server Error
+//   - CodeNotLeader: NotLeaderForPartition, retryable, 503 Service
+//     Unavailable
+//   - CodeTimeout: RequestTimedOut, retryable, 504 Gateway Timeout
+//   - CodeMessageTooLarge: MessageTooLarge, 413 Content Too Large
+//
+// Unknown topics are fatal, unlike for Kafka which retries them in case the
+// topic is being created, since they typically denote a misconfiguration.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeUnknownTopic,
+			Message:    "The message could not be delivered.",
+			Match:      Code(UnknownTopicOrPartition),
+			Severity:   errdecode.SeverityCritical,
+			HTTPStatus: http.StatusInternalServerError,
+			Tags:       []string{"kafka"},
+		},
+		{
+			Code:       CodeNotLeader,
+			Message:    "The message broker is unavailable, please try again later.",
+			Match:      Code(NotLeaderForPartition),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"kafka"},
+		},
+		{
+			Code:       CodeTimeout,
+			Message:    "The message broker timed out, please try again.",
+			Match:      Code(RequestTimedOut),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusGatewayTimeout,
+			Tags:       []string{"kafka"},
+		},
+		{
+			Code:       CodeMessageTooLarge,
+			Message:    "The message is too large.",
+			Match:      Code(MessageTooLarge),
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusRequestEntityTooLarge,
+			Tags:       []string{"kafka"},
+		},
+	}, options...)
+}
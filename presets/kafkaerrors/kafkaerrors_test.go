@@ -0,0 +1,44 @@
+package kafkaerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/kafkaerrors"
+	"github.com/twmb/franz-go/pkg/kerr"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(kafkaerrors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"sarama unknown topic", fmt.Errorf("produce: %w", sarama.ErrUnknownTopicOrPartition), kafkaerrors.CodeUnknownTopic, false},
+		{"sarama producer error", &sarama.ProducerError{Err: sarama.ErrNotLeaderForPartition}, kafkaerrors.CodeNotLeader, true},
+		{"sarama timeout", sarama.ErrRequestTimedOut, kafkaerrors.CodeTimeout, true},
+		{"franz-go not leader", fmt.Errorf("produce: %w", kerr.NotLeaderForPartition), kafkaerrors.CodeNotLeader, true},
+		{"franz-go message too large", kerr.MessageTooLarge, kafkaerrors.CodeMessageTooLarge, false},
+		{"other code", kerr.InvalidTopicException, 0, false},
+		{"other", errors.New("request timed out"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Package mongoerrors is a preset classifying the errors returned by the
+// MongoDB Go driver into not-found, conflict and infrastructure classes:
+//
+//	rules := append(appRules, mongoerrors.Rules()...)
+//
+// mongo.IsTimeout also reports context.DeadlineExceeded, so the rules of
+// ctxerrors should come first if both presets are merged.
+package mongoerrors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Codes of the preset rules.
+const (
+	CodeNotFound = 9701 + iota
+	CodeDuplicateKey
+	CodeWriteConflict
+	CodeTimeout
+	CodeUnavailable
+)
+
+// Server error codes of the preset rules, see
+// https://www.mongodb.com/docs/manual/reference/error-codes/.
+const (
+	HostUnreachable                 = 6
+	HostNotFound                    = 7
+	NetworkTimeout                  = 89
+	ShutdownInProgress              = 91
+	WriteConflict                   = 112
+	PrimarySteppedDown              = 189
+	NotWritablePrimary              = 10107
+	InterruptedAtShutdown           = 11600
+	InterruptedDueToReplStateChange = 11602
+	NotPrimaryNoSecondaryOk         = 13435
+	NotPrimaryOrSecondary           = 13436
+)
+
+// ErrorCode returns a matcher reporting whether an error is a
+// mongo.ServerError, e.g., a mongo.CommandError or a mongo.WriteException,
+// with one of the given codes.
+func ErrorCode(codes ...int) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var se mongo.ServerError
+		if !errors.As(err, &se) {
+			return false
+		}
+		for _, code := range codes {
+			if se.HasErrorCode(code) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ErrorLabel returns a matcher reporting whether an error is a
+// mongo.LabeledError with one of the given labels.
+func ErrorLabel(labels ...string) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var le mongo.LabeledError
+		if !errors.As(err, &le) {
+			return false
+		}
+		for _, label := range labels {
+			if le.HasErrorLabel(label) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeNotFound: mongo.ErrNoDocuments, 404 Not Found
+//   - CodeDuplicateKey: mongo.IsDuplicateKeyError, 409 Conflict
+//   - CodeWriteConflict: WriteConflict and the TransientTransactionError
+//     label, retryable, 409 Conflict
+//   - CodeTimeout: mongo.IsTimeout, retryable, 504 Gateway Timeout
+//   - CodeUnavailable: mongo.IsNetworkError, the RetryableWriteError label
+//     and the codes of unreachable or stepping down servers, retryable, 503
+//     Service Unavailable
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeNotFound,
+			Message:    "The requested document was not found.",
+			Match:      presets.Is(mongo.ErrNoDocuments),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusNotFound,
+			Tags:       []string{"mongodb"},
+		},
+		{
+			Code:       CodeDuplicateKey,
+			Message:    "The document already exists.",
+			Match:      mongo.IsDuplicateKeyError,
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"mongodb"},
+		},
+		{
+			Code:       CodeWriteConflict,
+			Message:    "The document was modified concurrently, please try again.",
+			Match:      presets.Any(ErrorCode(WriteConflict), ErrorLabel("TransientTransactionError")),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"mongodb"},
+		},
+		{
+			Code:       CodeTimeout,
+			Message:    "The database timed out, please try again.",
+			Match:      mongo.IsTimeout,
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusGatewayTimeout,
+			Tags:       []string{"mongodb"},
+		},
+		{
+			Code:    CodeUnavailable,
+			Message: "The database is unavailable, please try again later.",
+			Match: presets.Any(mongo.IsNetworkError, ErrorLabel("RetryableWriteError"), ErrorCode(
+				HostUnreachable, HostNotFound, NetworkTimeout, ShutdownInProgress, PrimarySteppedDown,
+				NotWritablePrimary, InterruptedAtShutdown, InterruptedDueToReplStateChange,
+				NotPrimaryNoSecondaryOk, NotPrimaryOrSecondary,
+			)),
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"mongodb"},
+		},
+	}, options...)
+}
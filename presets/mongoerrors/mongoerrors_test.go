@@ -0,0 +1,44 @@
+package mongoerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/mongoerrors"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(mongoerrors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"no documents", fmt.Errorf("find user: %w", mongo.ErrNoDocuments), mongoerrors.CodeNotFound, false},
+		{"duplicate key", mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "E11000 duplicate key error"}}}, mongoerrors.CodeDuplicateKey, false},
+		{"write conflict", mongo.CommandError{Code: 112, Name: "WriteConflict"}, mongoerrors.CodeWriteConflict, true},
+		{"transient transaction", mongo.CommandError{Code: 251, Labels: []string{"TransientTransactionError"}}, mongoerrors.CodeWriteConflict, true},
+		{"network", mongo.CommandError{Labels: []string{"NetworkError"}}, mongoerrors.CodeUnavailable, true},
+		{"stepped down", mongo.CommandError{Code: 10107, Name: "NotWritablePrimary"}, mongoerrors.CodeUnavailable, true},
+		{"other code", mongo.CommandError{Code: 2, Name: "BadValue"}, 0, false},
+		{"other", errors.New("no documents"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
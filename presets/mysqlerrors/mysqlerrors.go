@@ -0,0 +1,140 @@
+// Package mysqlerrors is a preset classifying MySQL errors reported by
+// github.com/go-sql-driver/mysql, by error number:
+//
+//	rules := append(appRules, mysqlerrors.Rules()...)
+//
+// Codes are shared with the presets of other database drivers, see
+// sqlerrors, and can be remapped with presets.Code and presets.Message.
+package mysqlerrors
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"github.com/iamrgon/errdecode/presets/sqlerrors"
+)
+
+// Error numbers of the preset rules, see
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html.
+const (
+	ErDupEntry           = 1062
+	ErRowIsReferenced    = 1451
+	ErNoReferencedRow    = 1452
+	ErCheckConstraint    = 3819
+	ErBadNullError       = 1048
+	ErLockDeadlock       = 1213
+	ErLockWaitTimeout    = 1205
+	ErServerShutdown     = 1053
+	ErConCountError      = 1040
+	ErTooManyUserConns   = 1203
+	ErLockNowaitConflict = 3572
+	ErNoReferencedRowOld = 1216
+	ErRowIsReferencedOld = 1217
+)
+
+// Number returns a matcher reporting whether an error is a *mysql.MySQLError
+// with one of the given error numbers.
+func Number(numbers ...uint16) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var myErr *mysql.MySQLError
+		if !errors.As(err, &myErr) {
+			return false
+		}
+		for _, n := range numbers {
+			if myErr.Number == n {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+var mysqlErrorType = reflect.TypeOf((*mysql.MySQLError)(nil))
+
+// Rules returns the preset rules customized with options:
+//
+//   - sqlerrors.CodeUniqueViolation: 1062, 409 Conflict
+//   - sqlerrors.CodeForeignKeyViolation: 1216, 1217, 1451 and 1452, 409
+//     Conflict
+//   - sqlerrors.CodeCheckViolation: 3819, 422 Unprocessable Entity
+//   - sqlerrors.CodeNotNullViolation: 1048, 422 Unprocessable Entity
+//   - sqlerrors.CodeDeadlock: 1213, retryable, 409 Conflict
+//   - sqlerrors.CodeLockTimeout: 1205 and 3572, retryable, 503 Service
+//     Unavailable
+//   - sqlerrors.CodeConnectionFailure: 1040, 1053, 1203 and
+//     mysql.ErrInvalidConn, retryable, 503 Service Unavailable
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       sqlerrors.CodeUniqueViolation,
+			Message:    "The record already exists.",
+			Types:      []reflect.Type{mysqlErrorType},
+			Match:      Number(ErDupEntry),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"sql", "mysql"},
+		},
+		{
+			Code:       sqlerrors.CodeForeignKeyViolation,
+			Message:    "The record references a record that does not exist, or is still referenced.",
+			Types:      []reflect.Type{mysqlErrorType},
+			Match:      Number(ErNoReferencedRowOld, ErRowIsReferencedOld, ErRowIsReferenced, ErNoReferencedRow),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"sql", "mysql"},
+		},
+		{
+			Code:       sqlerrors.CodeCheckViolation,
+			Message:    "The record is not valid.",
+			Types:      []reflect.Type{mysqlErrorType},
+			Match:      Number(ErCheckConstraint),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusUnprocessableEntity,
+			Tags:       []string{"sql", "mysql"},
+		},
+		{
+			Code:       sqlerrors.CodeNotNullViolation,
+			Message:    "A required value is missing.",
+			Types:      []reflect.Type{mysqlErrorType},
+			Match:      Number(ErBadNullError),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusUnprocessableEntity,
+			Tags:       []string{"sql", "mysql"},
+		},
+		{
+			Code:       sqlerrors.CodeDeadlock,
+			Message:    "The record was modified concurrently, please try again.",
+			Types:      []reflect.Type{mysqlErrorType},
+			Match:      Number(ErLockDeadlock),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"sql", "mysql"},
+		},
+		{
+			Code:       sqlerrors.CodeLockTimeout,
+			Message:    "The record is locked, please try again later.",
+			Types:      []reflect.Type{mysqlErrorType},
+			Match:      Number(ErLockWaitTimeout, ErLockNowaitConflict),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"sql", "mysql"},
+		},
+		{
+			Code:    sqlerrors.CodeConnectionFailure,
+			Message: "The database is unavailable.",
+			Match: func(err error) bool {
+				return errors.Is(err, mysql.ErrInvalidConn) || Number(ErConCountError, ErServerShutdown, ErTooManyUserConns)(err)
+			},
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"sql", "mysql"},
+		},
+	}, options...)
+}
@@ -0,0 +1,45 @@
+package mysqlerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"github.com/iamrgon/errdecode/presets/mysqlerrors"
+	"github.com/iamrgon/errdecode/presets/sqlerrors"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(mysqlerrors.Rules(presets.Code(sqlerrors.CodeDeadlock, 1500)))
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"duplicate", fmt.Errorf("insert user: %w", &mysql.MySQLError{Number: 1062}), sqlerrors.CodeUniqueViolation, false},
+		{"foreign key", &mysql.MySQLError{Number: 1452}, sqlerrors.CodeForeignKeyViolation, false},
+		{"deadlock", &mysql.MySQLError{Number: 1213}, 1500, true},
+		{"lock wait timeout", &mysql.MySQLError{Number: 1205}, sqlerrors.CodeLockTimeout, true},
+		{"invalid connection", fmt.Errorf("query: %w", mysql.ErrInvalidConn), sqlerrors.CodeConnectionFailure, true},
+		{"other number", &mysql.MySQLError{Number: 1064}, 0, false},
+		{"other error", errors.New("1062"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package neterrors is a preset classifying the transport-level errors of
+// package net, e.g., timeouts, DNS failures and refused connections:
+//
+//	rules := append(appRules, neterrors.Rules()...)
+//
+// Rules are ordered from the most specific to the most generic class, e.g.,
+// a refused connection is classified as such rather than as a generic
+// network error.
+package neterrors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeTimeout = 9201 + iota
+	CodeHostNotFound
+	CodeDNSFailure
+	CodeConnectionRefused
+	CodeConnectionReset
+	CodeNetwork
+)
+
+// IsTimeout reports whether err is a net.Error that timed out. Unlike
+// os.IsTimeout, context.DeadlineExceeded is not considered a network
+// timeout, see ctxerrors.
+func IsTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout() && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// isDNSError returns a matcher of *net.DNSError for which notFound reports
+// the given value.
+func isDNSError(notFound bool) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var dnsErr *net.DNSError
+		return errors.As(err, &dnsErr) && dnsErr.IsNotFound == notFound
+	}
+}
+
+func isOpError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeTimeout: net.Error timeouts, retryable, 504 Gateway Timeout
+//   - CodeHostNotFound: *net.DNSError for unknown hosts, 502 Bad Gateway
+//   - CodeDNSFailure: other *net.DNSError, retryable, 502 Bad Gateway
+//   - CodeConnectionRefused: syscall.ECONNREFUSED, retryable, 503 Service
+//     Unavailable
+//   - CodeConnectionReset: syscall.ECONNRESET and syscall.EPIPE, retryable,
+//     502 Bad Gateway
+//   - CodeNetwork: other *net.OpError, retryable, 502 Bad Gateway
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeTimeout,
+			Message:    "An upstream service timed out, please try again.",
+			Match:      IsTimeout,
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusGatewayTimeout,
+			Tags:       []string{"net"},
+		},
+		{
+			Code:       CodeHostNotFound,
+			Message:    "An upstream service could not be found.",
+			Match:      isDNSError(true),
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"net"},
+		},
+		{
+			Code:       CodeDNSFailure,
+			Message:    "An upstream service could not be resolved, please try again.",
+			Match:      isDNSError(false),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"net"},
+		},
+		{
+			Code:       CodeConnectionRefused,
+			Message:    "An upstream service is unavailable, please try again later.",
+			Match:      presets.Is(syscall.ECONNREFUSED),
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"net"},
+		},
+		{
+			Code:       CodeConnectionReset,
+			Message:    "The connection to an upstream service was lost, please try again.",
+			Match:      presets.Is(syscall.ECONNRESET, syscall.EPIPE),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"net"},
+		},
+		{
+			Code:       CodeNetwork,
+			Message:    "An upstream service could not be reached, please try again.",
+			Match:      isOpError,
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"net"},
+		},
+	}, options...)
+}
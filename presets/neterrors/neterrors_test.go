@@ -0,0 +1,52 @@
+package neterrors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/neterrors"
+)
+
+func opError(err error) error {
+	return &net.OpError{Op: "dial", Net: "tcp", Err: err}
+}
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(neterrors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"timeout", fmt.Errorf("get: %w", opError(os.ErrDeadlineExceeded)), neterrors.CodeTimeout, true},
+		{"dns timeout", &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}, neterrors.CodeTimeout, true},
+		{"host not found", opError(&net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}), neterrors.CodeHostNotFound, false},
+		{"dns failure", &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}, neterrors.CodeDNSFailure, true},
+		{"refused", opError(os.NewSyscallError("connect", syscall.ECONNREFUSED)), neterrors.CodeConnectionRefused, true},
+		{"reset", opError(os.NewSyscallError("read", syscall.ECONNRESET)), neterrors.CodeConnectionReset, true},
+		{"network", opError(errors.New("network is unreachable")), neterrors.CodeNetwork, true},
+		{"context deadline", context.DeadlineExceeded, 0, false},
+		{"other", errors.New("timeout"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
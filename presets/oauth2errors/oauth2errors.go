@@ -0,0 +1,160 @@
+// Package oauth2errors is a preset classifying the errors of OAuth 2.0 and
+// OpenID Connect providers, by error code, into authentication classes:
+//
+//	rules := append(appRules, oauth2errors.Rules()...)
+//
+// Errors of token requests made with golang.org/x/oauth2, i.e.,
+// *oauth2.RetrieveError, are supported, as well as the errors of
+// authorization responses parsed by FromQuery.
+//
+// The messages of the preset rules never include the descriptions sent by
+// providers, which are meant for developers.
+package oauth2errors
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"golang.org/x/oauth2"
+)
+
+// Codes of the preset rules.
+const (
+	CodeInvalidGrant = 9851 + iota
+	CodeInvalidClient
+	CodeAccessDenied
+	CodeLoginRequired
+	CodeUnavailable
+)
+
+// OAuth 2.0 and OpenID Connect error codes of the preset rules, see RFC 6749
+// section 5.2 and OpenID Connect Core section 3.1.2.6.
+const (
+	InvalidGrant           = "invalid_grant"
+	InvalidClient          = "invalid_client"
+	UnauthorizedClient     = "unauthorized_client"
+	AccessDenied           = "access_denied"
+	LoginRequired          = "login_required"
+	ConsentRequired        = "consent_required"
+	InteractionRequired    = "interaction_required"
+	ServerError            = "server_error"
+	TemporarilyUnavailable = "temporarily_unavailable"
+)
+
+// Error is an error of an authorization response, see FromQuery.
+type Error struct {
+	Code        string
+	Description string
+	URI         string
+}
+
+func (e *Error) Error() string {
+	if e.Description != "" {
+		return "oauth2: " + e.Code + ": " + e.Description
+	}
+	return "oauth2: " + e.Code
+}
+
+// FromQuery returns the error of an authorization response, i.e., the query
+// of the redirect URI, or nil if the response is not an error.
+func FromQuery(query url.Values) error {
+	code := query.Get("error")
+	if code == "" {
+		return nil
+	}
+	return &Error{Code: code, Description: query.Get("error_description"), URI: query.Get("error_uri")}
+}
+
+// ErrorCode returns the OAuth error code of the first OAuth error in the wrap
+// chain of err, if any.
+func ErrorCode(err error) (string, bool) {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return retrieveErr.ErrorCode, retrieveErr.ErrorCode != ""
+	}
+	var oauthErr *Error
+	if errors.As(err, &oauthErr) {
+		return oauthErr.Code, true
+	}
+	return "", false
+}
+
+// Code returns a matcher reporting whether an error is an OAuth error with one
+// of the given error codes.
+func Code(codes ...string) errdecode.MatcherFunc {
+	return func(err error) bool {
+		code, ok := ErrorCode(err)
+		if !ok {
+			return false
+		}
+		for _, c := range codes {
+			if code == c {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeInvalidGrant: invalid_grant, 401 Unauthorized
+//   - CodeInvalidClient: invalid_client and unauthorized_client, 500
+//     Internal Server Error
+//   - CodeAccessDenied: access_denied, 403 Forbidden
+//   - CodeLoginRequired: login_required, consent_required and
+//     interaction_required, 401 Unauthorized
+//   - CodeUnavailable: server_error and temporarily_unavailable, retryable,
+//     503 Service Unavailable
+//
+// Invalid grants, e.g., an expired refresh token, require the user to sign in
+// again. Invalid clients denote a misconfiguration of the application and are
+// not reported as authentication errors to users.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeInvalidGrant,
+			Message:    "Your session has expired, please sign in again.",
+			Match:      Code(InvalidGrant),
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusUnauthorized,
+			Tags:       []string{"oauth2", "auth"},
+		},
+		{
+			Code:       CodeInvalidClient,
+			Message:    "Sign in is unavailable.",
+			Match:      Code(InvalidClient, UnauthorizedClient),
+			Severity:   errdecode.SeverityCritical,
+			HTTPStatus: http.StatusInternalServerError,
+			Tags:       []string{"oauth2"},
+		},
+		{
+			Code:       CodeAccessDenied,
+			Message:    "Access was denied.",
+			Match:      Code(AccessDenied),
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusForbidden,
+			Tags:       []string{"oauth2", "auth"},
+		},
+		{
+			Code:       CodeLoginRequired,
+			Message:    "Please sign in to continue.",
+			Match:      Code(LoginRequired, ConsentRequired, InteractionRequired),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusUnauthorized,
+			Tags:       []string{"oauth2", "auth"},
+		},
+		{
+			Code:       CodeUnavailable,
+			Message:    "Sign in is temporarily unavailable, please try again later.",
+			Match:      Code(ServerError, TemporarilyUnavailable),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"oauth2"},
+		},
+	}, options...)
+}
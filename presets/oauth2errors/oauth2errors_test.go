@@ -0,0 +1,56 @@
+package oauth2errors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/oauth2errors"
+	"golang.org/x/oauth2"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(oauth2errors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"invalid grant", fmt.Errorf("refresh: %w", &oauth2.RetrieveError{ErrorCode: "invalid_grant"}), oauth2errors.CodeInvalidGrant, false},
+		{"invalid client", &oauth2.RetrieveError{ErrorCode: "invalid_client", ErrorDescription: "bad secret"}, oauth2errors.CodeInvalidClient, false},
+		{"unauthorized client", &oauth2.RetrieveError{ErrorCode: "unauthorized_client"}, oauth2errors.CodeInvalidClient, false},
+		{"access denied", oauth2errors.FromQuery(url.Values{"error": {"access_denied"}}), oauth2errors.CodeAccessDenied, false},
+		{"login required", oauth2errors.FromQuery(url.Values{"error": {"login_required"}}), oauth2errors.CodeLoginRequired, false},
+		{"temporarily unavailable", &oauth2.RetrieveError{ErrorCode: "temporarily_unavailable"}, oauth2errors.CodeUnavailable, true},
+		{"no error code", &oauth2.RetrieveError{Body: []byte("Bad Gateway")}, 0, false},
+		{"other code", &oauth2.RetrieveError{ErrorCode: "invalid_scope"}, 0, false},
+		{"other", errors.New("invalid_grant"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
+
+func TestFromQuery(t *testing.T) {
+	if err := oauth2errors.FromQuery(url.Values{"code": {"abc"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := oauth2errors.FromQuery(url.Values{"error": {"access_denied"}, "error_description": {"user cancelled"}})
+	if want := "oauth2: access_denied: user cancelled"; err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got=%v want=%s", err, want)
+	}
+}
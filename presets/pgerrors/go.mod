@@ -0,0 +1,20 @@
+module github.com/iamrgon/errdecode/presets/pgerrors
+
+go 1.21
+
+require (
+	github.com/go-sql-driver/mysql v1.8.1
+	github.com/iamrgon/errdecode v0.1.0
+	github.com/iamrgon/errdecode/presets/mysqlerrors v0.1.0
+	github.com/jackc/pgx/v5 v5.7.1
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	golang.org/x/crypto v0.27.0 // indirect
+	golang.org/x/text v0.18.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
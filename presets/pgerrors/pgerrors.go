@@ -0,0 +1,148 @@
+// Package pgerrors is a preset classifying PostgreSQL errors reported by
+// github.com/jackc/pgx/v5/pgconn, by SQLSTATE:
+//
+//	rules := append(appRules, pgerrors.Rules()...)
+//
+// Codes are shared with the presets of other database drivers, see
+// sqlerrors, and can be remapped per class of errors with presets.Code and
+// presets.Message.
+package pgerrors
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"github.com/iamrgon/errdecode/presets/sqlerrors"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes of the preset rules, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	UniqueViolation      = "23505"
+	ForeignKeyViolation  = "23503"
+	CheckViolation       = "23514"
+	NotNullViolation     = "23502"
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
+	LockNotAvailable     = "55P03"
+	ConnectionException  = "08" // class
+)
+
+// SQLState returns a matcher reporting whether an error is a *pgconn.PgError
+// with one of the given SQLSTATE codes. A code of two characters matches
+// the whole class, e.g., "23" for integrity constraint violations.
+func SQLState(codes ...string) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) {
+			return false
+		}
+		for _, code := range codes {
+			if pgErr.Code == code || len(code) == 2 && strings.HasPrefix(pgErr.Code, code) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+var pgErrorType = reflect.TypeOf((*pgconn.PgError)(nil))
+
+// Rules returns the preset rules customized with options:
+//
+//   - sqlerrors.CodeUniqueViolation: 23505, 409 Conflict
+//   - sqlerrors.CodeForeignKeyViolation: 23503, 409 Conflict
+//   - sqlerrors.CodeCheckViolation: 23514, 422 Unprocessable Entity
+//   - sqlerrors.CodeNotNullViolation: 23502, 422 Unprocessable Entity
+//   - sqlerrors.CodeSerializationFailure: 40001, retryable, 409 Conflict
+//   - sqlerrors.CodeDeadlock: 40P01, retryable, 409 Conflict
+//   - sqlerrors.CodeLockTimeout: 55P03, retryable, 503 Service Unavailable
+//   - sqlerrors.CodeConnectionFailure: class 08 and *pgconn.ConnectError,
+//     retryable, 503 Service Unavailable
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       sqlerrors.CodeUniqueViolation,
+			Message:    "The record already exists.",
+			Types:      []reflect.Type{pgErrorType},
+			Match:      SQLState(UniqueViolation),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"sql", "postgres"},
+		},
+		{
+			Code:       sqlerrors.CodeForeignKeyViolation,
+			Message:    "The record references a record that does not exist, or is still referenced.",
+			Types:      []reflect.Type{pgErrorType},
+			Match:      SQLState(ForeignKeyViolation),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"sql", "postgres"},
+		},
+		{
+			Code:       sqlerrors.CodeCheckViolation,
+			Message:    "The record is not valid.",
+			Types:      []reflect.Type{pgErrorType},
+			Match:      SQLState(CheckViolation),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusUnprocessableEntity,
+			Tags:       []string{"sql", "postgres"},
+		},
+		{
+			Code:       sqlerrors.CodeNotNullViolation,
+			Message:    "A required value is missing.",
+			Types:      []reflect.Type{pgErrorType},
+			Match:      SQLState(NotNullViolation),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusUnprocessableEntity,
+			Tags:       []string{"sql", "postgres"},
+		},
+		{
+			Code:       sqlerrors.CodeSerializationFailure,
+			Message:    "The record was modified concurrently, please try again.",
+			Types:      []reflect.Type{pgErrorType},
+			Match:      SQLState(SerializationFailure),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"sql", "postgres"},
+		},
+		{
+			Code:       sqlerrors.CodeDeadlock,
+			Message:    "The record was modified concurrently, please try again.",
+			Types:      []reflect.Type{pgErrorType},
+			Match:      SQLState(DeadlockDetected),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"sql", "postgres"},
+		},
+		{
+			Code:       sqlerrors.CodeLockTimeout,
+			Message:    "The record is locked, please try again later.",
+			Types:      []reflect.Type{pgErrorType},
+			Match:      SQLState(LockNotAvailable),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"sql", "postgres"},
+		},
+		{
+			Code:    sqlerrors.CodeConnectionFailure,
+			Message: "The database is unavailable.",
+			Match: func(err error) bool {
+				var connectErr *pgconn.ConnectError
+				return errors.As(err, &connectErr) || SQLState(ConnectionException)(err)
+			},
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"sql", "postgres"},
+		},
+	}, options...)
+}
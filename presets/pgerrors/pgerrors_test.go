@@ -0,0 +1,99 @@
+package pgerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"github.com/iamrgon/errdecode/presets/mysqlerrors"
+	"github.com/iamrgon/errdecode/presets/pgerrors"
+	"github.com/iamrgon/errdecode/presets/sqlerrors"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(pgerrors.Rules(
+		presets.Code(sqlerrors.CodeUniqueViolation, 1409),
+		presets.Message(sqlerrors.CodeUniqueViolation, "error.duplicate"),
+	))
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantMessage   string
+		wantRetryable bool
+	}{
+		{"unique violation", fmt.Errorf("insert user: %w", &pgconn.PgError{Code: "23505"}), 1409, "error.duplicate", false},
+		{"foreign key violation", &pgconn.PgError{Code: "23503"}, sqlerrors.CodeForeignKeyViolation, "", false},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, sqlerrors.CodeSerializationFailure, "", true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, sqlerrors.CodeDeadlock, "", true},
+		{"connection class", &pgconn.PgError{Code: "08006"}, sqlerrors.CodeConnectionFailure, "", true},
+		{"other state", &pgconn.PgError{Code: "42601"}, 0, "", false},
+		{"other error", errors.New("23505"), 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if !errors.As(err, &ce) {
+				return
+			}
+			if tt.wantMessage != "" && ce.Error() != tt.wantMessage {
+				t.Fatalf("unexpected message: got=%s want=%s", ce.Error(), tt.wantMessage)
+			}
+			if ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
+
+func TestSQLState(t *testing.T) {
+	tests := []struct {
+		codes []string
+		state string
+		want  bool
+	}{
+		{[]string{"23505"}, "23505", true},
+		{[]string{"23"}, "23503", true},
+		{[]string{"23505"}, "23503", false},
+		{[]string{"2"}, "23503", false},
+	}
+
+	for _, tt := range tests {
+		if got := pgerrors.SQLState(tt.codes...)(&pgconn.PgError{Code: tt.state}); got != tt.want {
+			t.Fatalf("unexpected match of %s by %v: got=%t want=%t", tt.state, tt.codes, got, tt.want)
+		}
+	}
+}
+
+func TestMergedRules(t *testing.T) {
+	dec := errdecode.New(append(pgerrors.Rules(), mysqlerrors.Rules()...))
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"postgres connection failure", &pgconn.PgError{Code: "08006"}, sqlerrors.CodeConnectionFailure},
+		{"mysql connection failure", mysql.ErrInvalidConn, sqlerrors.CodeConnectionFailure},
+		{"postgres unique violation", &pgconn.PgError{Code: "23505"}, sqlerrors.CodeUniqueViolation},
+		{"mysql unique violation", &mysql.MySQLError{Number: mysqlerrors.ErDupEntry}, sqlerrors.CodeUniqueViolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := errdecode.Code(dec.Translate(tt.err)); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package presets holds the options shared by the preset rule sets of its
+// subpackages, e.g., sqlerrors. Presets classify errors of the standard
+// library and of popular drivers, and are merged into the rule set of an
+// application:
+//
+//	rules := append(appRules, sqlerrors.Rules(
+//		presets.Code(sqlerrors.CodeNotFound, 1404),
+//		presets.Message(sqlerrors.CodeNotFound, "error.not_found"),
+//	)...)
+//
+// Every preset uses its own block of codes, in the range from 9000 to 9999,
+// so presets can be merged together. Codes shared by several presets
+// describe the same class of errors, e.g., a unique violation reported by any
+// database driver.
+package presets
+
+import (
+	"errors"
+
+	"github.com/iamrgon/errdecode"
+)
+
+// Option customizes a rule of a preset. It receives the code the preset
+// assigned to the rule, before any option was applied.
+type Option func(code int, rule *errdecode.Rule)
+
+// Code replaces the code of the preset rule with the given code.
+func Code(code, newCode int) Option {
+	return func(c int, rule *errdecode.Rule) {
+		if c == code {
+			rule.Code = newCode
+		}
+	}
+}
+
+// Message replaces the message of the preset rule with the given code, e.g.,
+// with a key for message translation.
+func Message(code int, message string) Option {
+	return func(c int, rule *errdecode.Rule) {
+		if c == code {
+			rule.Message = message
+		}
+	}
+}
+
+// Namespace sets the namespace of every preset rule, see
+// errdecode.RegisterRange.
+func Namespace(namespace string) Option {
+	return func(_ int, rule *errdecode.Rule) {
+		rule.Namespace = namespace
+	}
+}
+
+// Apply returns a copy of the rules of a preset customized with options. It
+// is meant for implementing presets.
+func Apply(rules []errdecode.Rule, options ...Option) []errdecode.Rule {
+	applied := make([]errdecode.Rule, len(rules))
+	for i, rule := range rules {
+		for _, option := range options {
+			option(rules[i].Code, &rule)
+		}
+		applied[i] = rule
+	}
+	return applied
+}
+
+// Is returns a matcher reporting whether any error in the wrap chain of an
+// error is one of targets, see errors.Is.
+func Is(targets ...error) errdecode.MatcherFunc {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Any returns a matcher reporting whether any of matchers matches an error.
+func Any(matchers ...errdecode.MatcherFunc) errdecode.MatcherFunc {
+	return func(err error) bool {
+		for _, m := range matchers {
+			if m(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+package presets_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+func TestApply(t *testing.T) {
+	rules := []errdecode.Rule{
+		{Code: 1, Message: "one"},
+		{Code: 2, Message: "two"},
+	}
+
+	got := presets.Apply(rules,
+		presets.Code(1, 2),
+		presets.Message(2, "deux"),
+		presets.Namespace("db"),
+	)
+
+	want := []struct {
+		code    int
+		message string
+	}{
+		{2, "one"},
+		{2, "deux"},
+	}
+	for i, w := range want {
+		if got[i].Code != w.code || got[i].Message != w.message || got[i].Namespace != "db" {
+			t.Fatalf("unexpected rule %d: got=%+v want=%+v", i, got[i], w)
+		}
+	}
+	if rules[0].Code != 1 || rules[1].Message != "two" {
+		t.Fatalf("unexpected change to preset rules: %+v", rules)
+	}
+}
+
+func TestIs(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	match := presets.Is(errA, errB)
+
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errA, true},
+		{fmt.Errorf("wrapped: %w", errB), true},
+		{errors.New("a"), false},
+	}
+	for _, tt := range tests {
+		if got := match(tt.err); got != tt.want {
+			t.Fatalf("unexpected match for %v: got=%t want=%t", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestAny(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	match := presets.Any(presets.Is(errA), presets.Is(errB))
+
+	if !match(errB) || match(errors.New("c")) {
+		t.Fatalf("unexpected matches")
+	}
+	if presets.Any()(errA) {
+		t.Fatalf("unexpected match without matchers")
+	}
+}
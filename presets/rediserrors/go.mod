@@ -0,0 +1,15 @@
+module github.com/iamrgon/errdecode/presets/rediserrors
+
+go 1.21
+
+require (
+	github.com/iamrgon/errdecode v0.1.0
+	github.com/redis/go-redis/v9 v9.6.3
+)
+
+require (
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
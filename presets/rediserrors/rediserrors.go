@@ -0,0 +1,111 @@
+// Package rediserrors is a preset classifying the errors returned by
+// github.com/redis/go-redis clients, telling cache misses from
+// infrastructure failures:
+//
+//	rules := append(appRules, rediserrors.Rules()...)
+//
+// redis.Nil is classified as a cache miss rather than as a failure, so it
+// should typically be handled before errors reach the decoder.
+package rediserrors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"github.com/redis/go-redis/v9"
+)
+
+// Codes of the preset rules.
+const (
+	CodeCacheMiss = 9651 + iota
+	CodeTimeout
+	CodePoolExhausted
+	CodeUnavailable
+)
+
+// UnavailablePrefixes are the prefixes of error replies of servers that
+// cannot serve a command for now, e.g., while loading their dataset.
+var UnavailablePrefixes = []string{"LOADING", "READONLY", "MOVED", "ASK", "CLUSTERDOWN", "TRYAGAIN", "MASTERDOWN"}
+
+// errPoolTimeout is the message of the unexported error of go-redis
+// reporting that no connection of the pool became available in time.
+const errPoolTimeout = "redis: connection pool timeout"
+
+// ErrorPrefix returns a matcher reporting whether an error is an error reply
+// whose first word is one of the given prefixes, see redis.HasErrorPrefix.
+func ErrorPrefix(prefixes ...string) errdecode.MatcherFunc {
+	return func(err error) bool {
+		for _, prefix := range prefixes {
+			if redis.HasErrorPrefix(err, prefix+" ") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout() && !errors.Is(err, context.DeadlineExceeded)
+}
+
+func isPoolTimeout(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if err.Error() == errPoolTimeout {
+			return true
+		}
+	}
+	return false
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeCacheMiss: redis.Nil, 404 Not Found
+//   - CodeTimeout: network timeouts, retryable, 504 Gateway Timeout
+//   - CodePoolExhausted: connection pool timeouts, retryable, 503 Service
+//     Unavailable
+//   - CodeUnavailable: UnavailablePrefixes, retryable, 503 Service
+//     Unavailable
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeCacheMiss,
+			Message:    "The requested entry was not found.",
+			Match:      presets.Is(redis.Nil),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusNotFound,
+			Tags:       []string{"redis"},
+		},
+		{
+			Code:       CodeTimeout,
+			Message:    "The cache timed out, please try again.",
+			Match:      isTimeout,
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusGatewayTimeout,
+			Tags:       []string{"redis"},
+		},
+		{
+			Code:       CodePoolExhausted,
+			Message:    "The cache is overloaded, please try again later.",
+			Match:      isPoolTimeout,
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"redis"},
+		},
+		{
+			Code:       CodeUnavailable,
+			Message:    "The cache is unavailable, please try again later.",
+			Match:      ErrorPrefix(UnavailablePrefixes...),
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"redis"},
+		},
+	}, options...)
+}
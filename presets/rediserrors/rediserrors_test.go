@@ -0,0 +1,54 @@
+package rediserrors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/rediserrors"
+	"github.com/redis/go-redis/v9"
+)
+
+// replyError mimics the error replies of servers.
+type replyError string
+
+func (e replyError) Error() string { return string(e) }
+func (replyError) RedisError()     {}
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(rediserrors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"nil", fmt.Errorf("get session: %w", redis.Nil), rediserrors.CodeCacheMiss, false},
+		{"timeout", &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, rediserrors.CodeTimeout, true},
+		{"pool timeout", fmt.Errorf("get session: %w", errors.New("redis: connection pool timeout")), rediserrors.CodePoolExhausted, true},
+		{"loading", replyError("LOADING Redis is loading the dataset in memory"), rediserrors.CodeUnavailable, true},
+		{"readonly", replyError("READONLY You can't write against a read only replica."), rediserrors.CodeUnavailable, true},
+		{"moved", replyError("MOVED 3999 127.0.0.1:6381"), rediserrors.CodeUnavailable, true},
+		{"other reply", replyError("WRONGTYPE Operation against a key holding the wrong kind of value"), 0, false},
+		{"context deadline", context.DeadlineExceeded, 0, false},
+		{"other", errors.New("LOADING"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
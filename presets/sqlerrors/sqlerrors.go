@@ -0,0 +1,94 @@
+// Package sqlerrors is a preset classifying the errors of database/sql:
+//
+//	rules := append(appRules, sqlerrors.Rules()...)
+//
+// It also declares the codes shared by the presets of database drivers,
+// e.g., CodeUniqueViolation. Matchers of the errors are registered for rule
+// catalogs under the IDs "sql.no_rows", "sql.tx_done", "sql.conn_done" and
+// "sql.bad_conn".
+package sqlerrors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeNotFound = 9101 + iota
+	CodeTxDone
+	CodeConnDone
+	CodeBadConn
+)
+
+// Codes shared by the presets of database drivers.
+const (
+	CodeUniqueViolation = 9110 + iota
+	CodeForeignKeyViolation
+	CodeCheckViolation
+	CodeNotNullViolation
+)
+
+// Codes shared by the presets of database drivers, for transient errors.
+const (
+	CodeSerializationFailure = 9120 + iota
+	CodeDeadlock
+	CodeLockTimeout
+	CodeConnectionFailure
+)
+
+func init() {
+	errdecode.RegisterMatcher("sql.no_rows", presets.Is(sql.ErrNoRows))
+	errdecode.RegisterMatcher("sql.tx_done", presets.Is(sql.ErrTxDone))
+	errdecode.RegisterMatcher("sql.conn_done", presets.Is(sql.ErrConnDone))
+	errdecode.RegisterMatcher("sql.bad_conn", presets.Is(driver.ErrBadConn))
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeNotFound: sql.ErrNoRows, 404 Not Found
+//   - CodeTxDone: sql.ErrTxDone, 500 Internal Server Error
+//   - CodeConnDone: sql.ErrConnDone, retryable, 503 Service Unavailable
+//   - CodeBadConn: driver.ErrBadConn, retryable, 503 Service Unavailable
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeNotFound,
+			Message:    "The requested record was not found.",
+			Match:      presets.Is(sql.ErrNoRows),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusNotFound,
+			Tags:       []string{"sql"},
+		},
+		{
+			Code:       CodeTxDone,
+			Message:    "The database transaction has already been completed.",
+			Match:      presets.Is(sql.ErrTxDone),
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusInternalServerError,
+			Tags:       []string{"sql"},
+		},
+		{
+			Code:       CodeConnDone,
+			Message:    "The database connection was closed.",
+			Match:      presets.Is(sql.ErrConnDone),
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"sql"},
+		},
+		{
+			Code:       CodeBadConn,
+			Message:    "The database connection is unavailable.",
+			Match:      presets.Is(driver.ErrBadConn),
+			Retryable:  true,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"sql"},
+		},
+	}, options...)
+}
@@ -0,0 +1,54 @@
+package sqlerrors_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"github.com/iamrgon/errdecode/presets/sqlerrors"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(sqlerrors.Rules(presets.Code(sqlerrors.CodeNotFound, 1404)))
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"no rows", fmt.Errorf("find order: %w", sql.ErrNoRows), 1404, false},
+		{"tx done", sql.ErrTxDone, sqlerrors.CodeTxDone, false},
+		{"conn done", sql.ErrConnDone, sqlerrors.CodeConnDone, true},
+		{"bad conn", fmt.Errorf("query: %w", driver.ErrBadConn), sqlerrors.CodeBadConn, true},
+		{"other", errors.New("syntax error"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	rules, err := errdecode.LoadJSON(strings.NewReader(`{"rules": [{"code": 1, "matcher": "sql.bad_conn"}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code := errdecode.Code(errdecode.New(rules).Translate(fmt.Errorf("ping: %w", driver.ErrBadConn))); code != 1 {
+		t.Fatalf("unexpected code: got=%d want=1", code)
+	}
+}
@@ -0,0 +1,26 @@
+//go:build cgo
+
+package sqliteerrors
+
+import (
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func init() {
+	extractors = append(extractors, mattnResultCode)
+}
+
+// mattnResultCode returns the result code of a sqlite3.Error of
+// github.com/mattn/go-sqlite3.
+func mattnResultCode(err error) (int, bool) {
+	var e sqlite3.Error
+	if !errors.As(err, &e) {
+		return 0, false
+	}
+	if e.ExtendedCode != 0 {
+		return int(e.ExtendedCode), true
+	}
+	return int(e.Code), true
+}
@@ -0,0 +1,135 @@
+// Package sqliteerrors is a preset classifying SQLite errors, by result
+// code:
+//
+//	rules := append(appRules, sqliteerrors.Rules()...)
+//
+// Errors of the modernc.org/sqlite driver are supported, as well as those of
+// github.com/mattn/go-sqlite3 when built with cgo, which the driver requires.
+// Codes are shared with the presets of other database drivers, see
+// sqlerrors, and can be remapped with presets.Code and presets.Message.
+package sqliteerrors
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+	"github.com/iamrgon/errdecode/presets/sqlerrors"
+)
+
+// Result codes of the preset rules, see https://www.sqlite.org/rescode.html.
+const (
+	Busy                 = 5
+	Locked               = 6
+	Constraint           = 19
+	ConstraintCheck      = 275
+	ConstraintForeignKey = 787
+	ConstraintNotNull    = 1299
+	ConstraintPrimaryKey = 1555
+	ConstraintUnique     = 2067
+)
+
+// extractors return the result code of an error of a given driver.
+var extractors = []func(err error) (int, bool){moderncResultCode}
+
+// ResultCode returns the extended result code of the first SQLite error in
+// the wrap chain of err, if any.
+func ResultCode(err error) (int, bool) {
+	for _, extract := range extractors {
+		if code, ok := extract(err); ok {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+// moderncResultCode returns the result code of a *sqlite.Error of
+// modernc.org/sqlite, without depending on the driver.
+func moderncResultCode(err error) (int, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		t := reflect.TypeOf(err)
+		if t.Kind() != reflect.Pointer || t.Elem().PkgPath() != "modernc.org/sqlite" || t.Elem().Name() != "Error" {
+			continue
+		}
+		if e, ok := err.(interface{ Code() int }); ok {
+			return e.Code(), true
+		}
+	}
+	return 0, false
+}
+
+// Code returns a matcher reporting whether an error is an SQLite error with
+// one of the given result codes. A primary result code, e.g., Constraint,
+// matches all its extended result codes, e.g., ConstraintUnique.
+func Code(codes ...int) errdecode.MatcherFunc {
+	return func(err error) bool {
+		rc, ok := ResultCode(err)
+		if !ok {
+			return false
+		}
+		for _, code := range codes {
+			if rc == code || code < 256 && rc&0xff == code {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - sqlerrors.CodeUniqueViolation: ConstraintUnique and
+//     ConstraintPrimaryKey, 409 Conflict
+//   - sqlerrors.CodeForeignKeyViolation: ConstraintForeignKey, 409 Conflict
+//   - sqlerrors.CodeCheckViolation: ConstraintCheck, 422 Unprocessable Entity
+//   - sqlerrors.CodeNotNullViolation: ConstraintNotNull, 422 Unprocessable
+//     Entity
+//   - sqlerrors.CodeLockTimeout: Busy and Locked, retryable, 503 Service
+//     Unavailable
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       sqlerrors.CodeUniqueViolation,
+			Message:    "The record already exists.",
+			Match:      Code(ConstraintUnique, ConstraintPrimaryKey),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"sql", "sqlite"},
+		},
+		{
+			Code:       sqlerrors.CodeForeignKeyViolation,
+			Message:    "The record references a record that does not exist, or is still referenced.",
+			Match:      Code(ConstraintForeignKey),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusConflict,
+			Tags:       []string{"sql", "sqlite"},
+		},
+		{
+			Code:       sqlerrors.CodeCheckViolation,
+			Message:    "The record is not valid.",
+			Match:      Code(ConstraintCheck),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusUnprocessableEntity,
+			Tags:       []string{"sql", "sqlite"},
+		},
+		{
+			Code:       sqlerrors.CodeNotNullViolation,
+			Message:    "A required value is missing.",
+			Match:      Code(ConstraintNotNull),
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusUnprocessableEntity,
+			Tags:       []string{"sql", "sqlite"},
+		},
+		{
+			Code:       sqlerrors.CodeLockTimeout,
+			Message:    "The database is busy, please try again later.",
+			Match:      Code(Busy, Locked),
+			Retryable:  true,
+			Severity:   errdecode.SeverityWarning,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"sql", "sqlite"},
+		},
+	}, options...)
+}
@@ -0,0 +1,65 @@
+//go:build cgo
+
+package sqliteerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/sqlerrors"
+	"github.com/iamrgon/errdecode/presets/sqliteerrors"
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(sqliteerrors.Rules())
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantRetryable bool
+	}{
+		{"unique", fmt.Errorf("insert: %w", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}), sqlerrors.CodeUniqueViolation, false},
+		{"primary key", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintPrimaryKey}, sqlerrors.CodeUniqueViolation, false},
+		{"not null", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintNotNull}, sqlerrors.CodeNotNullViolation, false},
+		{"busy", sqlite3.Error{Code: sqlite3.ErrBusy}, sqlerrors.CodeLockTimeout, true},
+		{"locked", sqlite3.Error{Code: sqlite3.ErrLocked, ExtendedCode: sqlite3.ErrLockedSharedCache}, sqlerrors.CodeLockTimeout, true},
+		{"other code", sqlite3.Error{Code: sqlite3.ErrError}, 0, false},
+		{"other error", errors.New("constraint failed"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Retryable() != tt.wantRetryable {
+				t.Fatalf("unexpected retryable: got=%t want=%t", ce.Retryable(), tt.wantRetryable)
+			}
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	unique := sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}
+	tests := []struct {
+		codes []int
+		want  bool
+	}{
+		{[]int{sqliteerrors.ConstraintUnique}, true},
+		{[]int{sqliteerrors.Constraint}, true},
+		{[]int{sqliteerrors.ConstraintCheck}, false},
+		{[]int{sqliteerrors.Busy}, false},
+	}
+
+	for _, tt := range tests {
+		if got := sqliteerrors.Code(tt.codes...)(unique); got != tt.want {
+			t.Fatalf("unexpected match by %v: got=%t want=%t", tt.codes, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Package syscallerrors is a preset classifying common syscall.Errno values
+// into operational classes, with severities telling errors that warrant
+// paging an operator, e.g., a full disk, from those that can be ignored,
+// e.g., a connection reset by a client:
+//
+//	rules := append(appRules, syscallerrors.Rules()...)
+package syscallerrors
+
+import (
+	"net/http"
+	"syscall"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeConnectionReset = 9351 + iota
+	CodeBrokenPipe
+	CodeNoSpace
+	CodeTooManyOpenFiles
+)
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeConnectionReset: ECONNRESET, retryable, info, 502 Bad Gateway
+//   - CodeBrokenPipe: EPIPE, retryable, info, 502 Bad Gateway
+//   - CodeNoSpace: ENOSPC and EDQUOT, critical, 507 Insufficient Storage
+//   - CodeTooManyOpenFiles: EMFILE and ENFILE, retryable, critical, 503
+//     Service Unavailable
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeConnectionReset,
+			Message:    "The connection was reset, please try again.",
+			Match:      presets.Is(syscall.ECONNRESET),
+			Retryable:  true,
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"syscall"},
+		},
+		{
+			Code:       CodeBrokenPipe,
+			Message:    "The connection was closed, please try again.",
+			Match:      presets.Is(syscall.EPIPE),
+			Retryable:  true,
+			Severity:   errdecode.SeverityInfo,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"syscall"},
+		},
+		{
+			Code:       CodeNoSpace,
+			Message:    "The server is out of storage space.",
+			Match:      presets.Is(syscall.ENOSPC, syscall.EDQUOT),
+			Severity:   errdecode.SeverityCritical,
+			HTTPStatus: http.StatusInsufficientStorage,
+			Tags:       []string{"syscall"},
+		},
+		{
+			Code:       CodeTooManyOpenFiles,
+			Message:    "The server is overloaded, please try again later.",
+			Match:      presets.Is(syscall.EMFILE, syscall.ENFILE),
+			Retryable:  true,
+			Severity:   errdecode.SeverityCritical,
+			HTTPStatus: http.StatusServiceUnavailable,
+			Tags:       []string{"syscall"},
+		},
+	}, options...)
+}
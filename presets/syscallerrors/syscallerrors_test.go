@@ -0,0 +1,43 @@
+package syscallerrors_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/syscallerrors"
+)
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(syscallerrors.Rules())
+
+	tests := []struct {
+		name         string
+		err          error
+		wantCode     int
+		wantSeverity errdecode.Severity
+	}{
+		{"reset", os.NewSyscallError("read", syscall.ECONNRESET), syscallerrors.CodeConnectionReset, errdecode.SeverityInfo},
+		{"broken pipe", os.NewSyscallError("write", syscall.EPIPE), syscallerrors.CodeBrokenPipe, errdecode.SeverityInfo},
+		{"disk full", &fs.PathError{Op: "write", Path: "data.db", Err: syscall.ENOSPC}, syscallerrors.CodeNoSpace, errdecode.SeverityCritical},
+		{"too many open files", &fs.PathError{Op: "open", Path: "data.db", Err: syscall.EMFILE}, syscallerrors.CodeTooManyOpenFiles, errdecode.SeverityCritical},
+		{"other errno", syscall.EINVAL, 0, errdecode.SeverityUnspecified},
+		{"other", errors.New("no space left on device"), 0, errdecode.SeverityUnspecified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dec.Translate(tt.err)
+			if code := errdecode.Code(err); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d", code, tt.wantCode)
+			}
+			var ce errdecode.ClassifiedError
+			if errors.As(err, &ce) && ce.Severity() != tt.wantSeverity {
+				t.Fatalf("unexpected severity: got=%v want=%v", ce.Severity(), tt.wantSeverity)
+			}
+		})
+	}
+}
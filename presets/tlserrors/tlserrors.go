@@ -0,0 +1,123 @@
+// Package tlserrors is a preset classifying the TLS handshake and
+// certificate verification errors of packages crypto/tls and crypto/x509
+// into operational classes:
+//
+//	rules := append(appRules, tlserrors.Rules()...)
+//	rules = append(rules, neterrors.Rules()...)
+//
+// TLS errors may be wrapped in *net.OpError, so the preset rules must come
+// before the ones of neterrors to be classified as such.
+package tlserrors
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets"
+)
+
+// Codes of the preset rules.
+const (
+	CodeUnknownAuthority = 9951 + iota
+	CodeCertificateExpired
+	CodeCertificateInvalid
+	CodeHostnameMismatch
+	CodeNotTLS
+)
+
+func isUnknownAuthority(err error) bool {
+	var authErr x509.UnknownAuthorityError
+	return errors.As(err, &authErr)
+}
+
+// isCertificateInvalid returns a matcher of x509.CertificateInvalidError for
+// which expired reports whether the certificate expired.
+func isCertificateInvalid(expired bool) errdecode.MatcherFunc {
+	return func(err error) bool {
+		var certErr x509.CertificateInvalidError
+		return errors.As(err, &certErr) && (certErr.Reason == x509.Expired) == expired
+	}
+}
+
+func isHostnameMismatch(err error) bool {
+	var hostErr x509.HostnameError
+	return errors.As(err, &hostErr)
+}
+
+// errHTTPResponse is the message of the error returned by net/http clients
+// instead of tls.RecordHeaderError when the server responded in plain HTTP.
+const errHTTPResponse = "http: server gave HTTP response to HTTPS client"
+
+func isRecordHeader(err error) bool {
+	var recordErr tls.RecordHeaderError
+	if errors.As(err, &recordErr) {
+		return true
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		if err.Error() == errHTTPResponse {
+			return true
+		}
+	}
+	return false
+}
+
+// Rules returns the preset rules customized with options:
+//
+//   - CodeUnknownAuthority: x509.UnknownAuthorityError, 502 Bad Gateway
+//   - CodeCertificateExpired: x509.CertificateInvalidError for expired or
+//     not yet valid certificates, 502 Bad Gateway
+//   - CodeCertificateInvalid: other x509.CertificateInvalidError, 502 Bad
+//     Gateway
+//   - CodeHostnameMismatch: x509.HostnameError, 502 Bad Gateway
+//   - CodeNotTLS: tls.RecordHeaderError, e.g., when the peer does not speak
+//     TLS, and its net/http counterpart, 502 Bad Gateway
+//
+// None of the preset rules are retryable, since they denote a misconfiguration
+// of either the application or the peer.
+func Rules(options ...presets.Option) []errdecode.Rule {
+	return presets.Apply([]errdecode.Rule{
+		{
+			Code:       CodeUnknownAuthority,
+			Message:    "A secure connection to an upstream service could not be established.",
+			Match:      isUnknownAuthority,
+			Severity:   errdecode.SeverityCritical,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"tls"},
+		},
+		{
+			Code:       CodeCertificateExpired,
+			Message:    "A secure connection to an upstream service could not be established.",
+			Match:      isCertificateInvalid(true),
+			Severity:   errdecode.SeverityCritical,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"tls"},
+		},
+		{
+			Code:       CodeCertificateInvalid,
+			Message:    "A secure connection to an upstream service could not be established.",
+			Match:      isCertificateInvalid(false),
+			Severity:   errdecode.SeverityCritical,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"tls"},
+		},
+		{
+			Code:       CodeHostnameMismatch,
+			Message:    "A secure connection to an upstream service could not be established.",
+			Match:      isHostnameMismatch,
+			Severity:   errdecode.SeverityCritical,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"tls"},
+		},
+		{
+			Code:       CodeNotTLS,
+			Message:    "A secure connection to an upstream service could not be established.",
+			Match:      isRecordHeader,
+			Severity:   errdecode.SeverityError,
+			HTTPStatus: http.StatusBadGateway,
+			Tags:       []string{"tls"},
+		},
+	}, options...)
+}
@@ -0,0 +1,62 @@
+package tlserrors_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/tlserrors"
+)
+
+func get(t *testing.T, client *http.Client, url string) error {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected an error")
+	}
+	return err
+}
+
+func TestRules(t *testing.T) {
+	dec := errdecode.New(tlserrors.Rules())
+
+	tlsSrv := httptest.NewUnstartedServer(http.NotFoundHandler())
+	tlsSrv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	tlsSrv.StartTLS()
+	defer tlsSrv.Close()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	mismatch := tlsSrv.Client()
+	mismatch.Transport.(*http.Transport).TLSClientConfig.ServerName = "other.example"
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"unknown authority", get(t, &http.Client{}, tlsSrv.URL), tlserrors.CodeUnknownAuthority},
+		{"hostname mismatch", get(t, mismatch, tlsSrv.URL), tlserrors.CodeHostnameMismatch},
+		{"record header", tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}, tlserrors.CodeNotTLS},
+		{"not TLS", get(t, tlsSrv.Client(), "https://"+srv.Listener.Addr().String()), tlserrors.CodeNotTLS},
+		{"expired", fmt.Errorf("verify: %w", x509.CertificateInvalidError{Reason: x509.Expired}), tlserrors.CodeCertificateExpired},
+		{"invalid", &tls.CertificateVerificationError{Err: x509.CertificateInvalidError{Reason: x509.NotAuthorizedToSign}}, tlserrors.CodeCertificateInvalid},
+		{"other", errors.New("tls: handshake failure"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := errdecode.Code(dec.Translate(tt.err)); code != tt.wantCode {
+				t.Fatalf("unexpected code: got=%d want=%d (%v)", code, tt.wantCode, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package validatorerrors classifies the errors of
+// github.com/go-playground/validator as field errors, with a code and a
+// message per validation tag:
+//
+//	decoder := errdecode.New(rules, validatorerrors.FieldRules(validatorerrors.Tags))
+//
+//	if err := validate.Struct(req); err != nil {
+//		return validatorerrors.Expand(err)
+//	}
+//
+// Expanded errors are translated one field at a time by
+// errdecode.Decoder.TranslateAll, see errdecode.ClassifiedFieldError.
+package validatorerrors
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/iamrgon/errdecode"
+)
+
+// Codes of the default tags.
+const (
+	CodeRequired = 9801 + iota
+	CodeEmail
+	CodeMin
+	CodeMax
+)
+
+// Tag is the classification of the failures of a validation tag. The
+// "{field}" placeholder of the message is replaced by the name of the field,
+// and "{param}" by the parameter of the tag, e.g., 8 for "min=8".
+type Tag struct {
+	Code    int
+	Message string
+}
+
+// Tags are the default classifications of validation tags.
+var Tags = map[string]Tag{
+	"required": {CodeRequired, "The field {field} is required."},
+	"email":    {CodeEmail, "The field {field} must be a valid email address."},
+	"min":      {CodeMin, "The field {field} must be at least {param}."},
+	"max":      {CodeMax, "The field {field} must be at most {param}."},
+}
+
+// FieldRules is used to classify the field errors of the given tags, see
+// errdecode.FieldRules.
+func FieldRules(tags map[string]Tag) errdecode.Option {
+	frs := make([]errdecode.FieldRule, 0, len(tags))
+	for kind, tag := range tags {
+		frs = append(frs, errdecode.FieldRule{Kind: kind, Code: tag.Code, Message: tag.Message})
+	}
+	return errdecode.FieldRules(frs...)
+}
+
+// Expand returns validator.ValidationErrors as joined field errors, one per
+// failed field, see errdecode.FieldError. Other errors are returned as-is.
+//
+// The field of an error is its namespace without the name of the validated
+// struct, e.g., "Address.City", so that nested fields can be told apart.
+// The kind of an error is the failed tag, e.g., "required".
+func Expand(err error) error {
+	var ves validator.ValidationErrors
+	if !errors.As(err, &ves) {
+		return err
+	}
+	errs := make([]error, len(ves))
+	for i, ve := range ves {
+		errs[i] = &fieldError{ve}
+	}
+	return errors.Join(errs...)
+}
+
+type fieldError struct {
+	validator.FieldError
+}
+
+func (e *fieldError) Kind() string  { return e.Tag() }
+func (e *fieldError) Error() string { return e.FieldError.Error() }
+
+// Field returns the namespace of the field without the name of the struct.
+func (e *fieldError) Field() string {
+	ns := e.Namespace()
+	if i := strings.IndexByte(ns, '.'); i >= 0 {
+		return ns[i+1:]
+	}
+	return ns
+}
+
+// Fields returns the parameter of the tag, see errdecode.Fielder.
+func (e *fieldError) Fields() map[string]interface{} {
+	if e.Param() == "" {
+		return nil
+	}
+	return map[string]interface{}{"param": e.Param()}
+}
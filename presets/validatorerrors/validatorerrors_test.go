@@ -0,0 +1,56 @@
+package validatorerrors_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/presets/validatorerrors"
+)
+
+type signup struct {
+	Email    string `validate:"required,email"`
+	Password string `validate:"min=8"`
+	Address  struct {
+		City string `validate:"required"`
+	}
+	Age int `validate:"max=150"`
+}
+
+func TestExpand(t *testing.T) {
+	dec := errdecode.New(nil, validatorerrors.FieldRules(validatorerrors.Tags))
+
+	err := validator.New().Struct(signup{Email: "gopher", Password: "hunter2", Age: 200})
+	translated := dec.TranslateAll(validatorerrors.Expand(err))
+
+	want := []struct {
+		field   string
+		code    int
+		message string
+	}{
+		{"Email", validatorerrors.CodeEmail, "The field Email must be a valid email address."},
+		{"Password", validatorerrors.CodeMin, "The field Password must be at least 8."},
+		{"Address.City", validatorerrors.CodeRequired, "The field Address.City is required."},
+		{"Age", validatorerrors.CodeMax, "The field Age must be at most 150."},
+	}
+	if len(translated) != len(want) {
+		t.Fatalf("unexpected number of errors: got=%d want=%d", len(translated), len(want))
+	}
+	for i, w := range want {
+		var fe errdecode.ClassifiedFieldError
+		if !errors.As(translated[i], &fe) {
+			t.Fatalf("unexpected error %d: got=%v want a field error", i, translated[i])
+		}
+		if fe.Field() != w.field || fe.Code() != w.code || fe.Error() != w.message {
+			t.Fatalf("unexpected error %d: got=%s/%d/%s want=%s/%d/%s", i, fe.Field(), fe.Code(), fe.Error(), w.field, w.code, w.message)
+		}
+	}
+}
+
+func TestExpandOther(t *testing.T) {
+	err := errors.New("boom")
+	if got := validatorerrors.Expand(err); got != err {
+		t.Fatalf("unexpected error: got=%v want=%v", got, err)
+	}
+}
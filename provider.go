@@ -0,0 +1,93 @@
+package errdecode
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Provider is a preset rule set shipped by a library, e.g., the client of a
+// third-party API, along with the range of codes it owns.
+type Provider interface {
+	// Name is the name of the provider, e.g., "acme.billing". It is the
+	// namespace of its rules, see Rule.Namespace.
+	Name() string
+
+	// Rules returns the rules of the provider.
+	Rules() []Rule
+
+	// CodeRange returns the range of codes of the rules, from lo to hi,
+	// inclusive.
+	CodeRange() (lo, hi int)
+}
+
+var providers = make(map[string]Provider)
+
+// Register makes a provider available to applications, see Providers and
+// Mount. It is typically called from an init function of the package
+// declaring the provider. The code range of the provider is registered under
+// its name, see RegisterRange.
+//
+// It panics if the name is registered twice, or if the code range is empty
+// or overlaps a registered range.
+func Register(p Provider) {
+	registry.Lock()
+	defer registry.Unlock()
+	name := p.Name()
+	if _, dup := providers[name]; dup {
+		panic("errdecode: Register called twice for " + name)
+	}
+	lo, hi := p.CodeRange()
+	registerRange(name, lo, hi)
+	providers[name] = p
+}
+
+// Providers returns the registered providers, sorted by name.
+func Providers() []Provider {
+	registry.RLock()
+	defer registry.RUnlock()
+	ps := make([]Provider, 0, len(providers))
+	for _, p := range providers {
+		ps = append(ps, p)
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i].Name() < ps[j].Name() })
+	return ps
+}
+
+// Mount returns the rules of the registered providers with the given names,
+// or of every registered provider if no names are given, to be merged into
+// the rule set of an application:
+//
+//	mounted, err := errdecode.Mount("acme.billing")
+//	if err != nil {
+//		return err
+//	}
+//	decoder := errdecode.New(append(appRules, mounted...))
+//
+// The namespace of the rules is set to the name of their provider, so New
+// panics if a provider returns rules outside of its code range.
+func Mount(names ...string) ([]Rule, error) {
+	var ps []Provider
+	if len(names) == 0 {
+		ps = Providers()
+	} else {
+		registry.RLock()
+		for _, name := range names {
+			p, ok := providers[name]
+			if !ok {
+				registry.RUnlock()
+				return nil, fmt.Errorf("%w: provider %q", ErrUnregistered, name)
+			}
+			ps = append(ps, p)
+		}
+		registry.RUnlock()
+	}
+
+	var rules []Rule
+	for _, p := range ps {
+		for _, rule := range p.Rules() {
+			rule.Namespace = p.Name()
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
@@ -0,0 +1,86 @@
+package errdecode_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+var errProviderDeclined = errors.New("card declined")
+
+type testProvider struct {
+	name   string
+	lo, hi int
+	rules  []errdecode.Rule
+}
+
+func (p testProvider) Name() string            { return p.name }
+func (p testProvider) Rules() []errdecode.Rule { return p.rules }
+func (p testProvider) CodeRange() (lo, hi int) { return p.lo, p.hi }
+
+func init() {
+	errdecode.Register(testProvider{"provider.payments", 93000, 93099, []errdecode.Rule{
+		{Code: 93001, Message: "Card declined.", Errors: []error{errProviderDeclined}},
+	}})
+	errdecode.Register(testProvider{"provider.shipping", 93100, 93199, []errdecode.Rule{
+		{Code: 93101, Message: "Address not found."},
+	}})
+}
+
+func TestMount(t *testing.T) {
+	rules, err := errdecode.Mount("provider.payments")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Namespace != "provider.payments" {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+
+	dec := errdecode.New(rules)
+	if code := errdecode.Code(dec.Translate(errProviderDeclined)); code != 93001 {
+		t.Fatalf("unexpected code: got=%d want=93001", code)
+	}
+
+	if _, err := errdecode.Mount("provider.unknown"); !errors.Is(err, errdecode.ErrUnregistered) {
+		t.Fatalf("unexpected error: got=%v want=%v", err, errdecode.ErrUnregistered)
+	}
+}
+
+func TestMountAll(t *testing.T) {
+	rules, err := errdecode.Mount()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	codes := make(map[int]string)
+	for _, rule := range rules {
+		codes[rule.Code] = rule.Namespace
+	}
+	if codes[93001] != "provider.payments" || codes[93101] != "provider.shipping" {
+		t.Fatalf("unexpected namespaces: %v", codes)
+	}
+}
+
+func TestRegisterOutOfRange(t *testing.T) {
+	errdecode.Register(testProvider{"provider.inventory", 93200, 93299, []errdecode.Rule{{Code: 93001}}})
+	rules, err := errdecode.Mount("provider.inventory")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		r := recover()
+		if err, _ := r.(error); !errors.Is(err, errdecode.ErrCodeOutOfRange) {
+			t.Fatalf("unexpected panic: got=%v want=%v", r, errdecode.ErrCodeOutOfRange)
+		}
+	}()
+	errdecode.New(rules)
+}
+
+func TestRegisterTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	errdecode.Register(testProvider{"provider.payments", 93900, 93999, nil})
+}
@@ -0,0 +1,90 @@
+package errdecode
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrCodeOutOfRange is returned when a rule code falls outside the range
+// registered for its namespace, see RegisterRange.
+var ErrCodeOutOfRange = errors.New("errdecode: code out of range")
+
+type codeRange struct {
+	name   string
+	lo, hi int
+}
+
+var ranges struct {
+	list   []codeRange // sorted by lo
+	byName map[string]codeRange
+}
+
+// RegisterRange reserves the codes from lo to hi, inclusive, for the
+// namespace name, e.g., a team or a service. Once a range is registered, New
+// and Catalog.Compile require rules of the namespace, see Rule.Namespace, to
+// use codes of the range, and other rules to stay out of it.
+//
+// It panics if the name is registered twice, if lo is greater than hi, or if
+// the range overlaps a registered range.
+func RegisterRange(name string, lo, hi int) {
+	registry.Lock()
+	defer registry.Unlock()
+	registerRange(name, lo, hi)
+}
+
+// registerRange is RegisterRange with the registry locked.
+func registerRange(name string, lo, hi int) {
+	if lo > hi {
+		panic(fmt.Sprintf("errdecode: RegisterRange called with empty range [%d, %d] for %s", lo, hi, name))
+	}
+	if _, dup := ranges.byName[name]; dup {
+		panic("errdecode: RegisterRange called twice for " + name)
+	}
+	for _, r := range ranges.list {
+		if lo <= r.hi && r.lo <= hi {
+			panic(fmt.Sprintf("errdecode: range [%d, %d] of %s overlaps range [%d, %d] of %s", lo, hi, name, r.lo, r.hi, r.name))
+		}
+	}
+	if ranges.byName == nil {
+		ranges.byName = make(map[string]codeRange)
+	}
+	r := codeRange{name, lo, hi}
+	ranges.byName[name] = r
+	ranges.list = append(ranges.list, r)
+	sort.Slice(ranges.list, func(i, j int) bool { return ranges.list[i].lo < ranges.list[j].lo })
+}
+
+// checkRanges verifies the codes of rules against the registered ranges.
+func checkRanges(rs []Rule) error {
+	registry.RLock()
+	defer registry.RUnlock()
+	if len(ranges.list) == 0 {
+		return nil
+	}
+	for _, rule := range rs {
+		if err := checkRange(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkRange(rule Rule) error {
+	if rule.Namespace != "" {
+		r, ok := ranges.byName[rule.Namespace]
+		if !ok {
+			return fmt.Errorf("rule %d: %w: namespace %q", rule.Code, ErrUnregistered, rule.Namespace)
+		}
+		if rule.Code < r.lo || rule.Code > r.hi {
+			return fmt.Errorf("rule %d: %w [%d, %d] of %s", rule.Code, ErrCodeOutOfRange, r.lo, r.hi, r.name)
+		}
+		return nil
+	}
+	i := sort.Search(len(ranges.list), func(i int) bool { return ranges.list[i].hi >= rule.Code })
+	if i < len(ranges.list) && ranges.list[i].lo <= rule.Code {
+		r := ranges.list[i]
+		return fmt.Errorf("rule %d: %w: reserved by %s, set Namespace", rule.Code, ErrCodeOutOfRange, r.name)
+	}
+	return nil
+}
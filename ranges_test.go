@@ -0,0 +1,68 @@
+package errdecode_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func init() {
+	errdecode.RegisterRange("ranges.billing", 90000, 90999)
+	errdecode.RegisterRange("ranges.identity", 91000, 91999)
+}
+
+func TestRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    errdecode.Rule
+		wantErr error
+	}{
+		{"in range", errdecode.Rule{Code: 90001, Namespace: "ranges.billing"}, nil},
+		{"range bounds", errdecode.Rule{Code: 91999, Namespace: "ranges.identity"}, nil},
+		{"no namespace outside ranges", errdecode.Rule{Code: 92000}, nil},
+		{"out of range", errdecode.Rule{Code: 91001, Namespace: "ranges.billing"}, errdecode.ErrCodeOutOfRange},
+		{"reserved code", errdecode.Rule{Code: 90500}, errdecode.ErrCodeOutOfRange},
+		{"unregistered namespace", errdecode.Rule{Code: 90001, Namespace: "ranges.unknown"}, errdecode.ErrUnregistered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got error
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						got, _ = r.(error)
+					}
+				}()
+				errdecode.New([]errdecode.Rule{tt.rule})
+			}()
+			if !errors.Is(got, tt.wantErr) {
+				t.Fatalf("unexpected panic: got=%v want=%v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRangesCatalog(t *testing.T) {
+	const catalog = `
+rules:
+  - code: 91500
+    message: error.billing
+    namespace: ranges.billing
+`
+	_, err := errdecode.LoadYAML(strings.NewReader(catalog))
+	if !errors.Is(err, errdecode.ErrCodeOutOfRange) {
+		t.Fatalf("unexpected error: got=%v want=%v", err, errdecode.ErrCodeOutOfRange)
+	}
+}
+
+func TestRegisterRangeOverlap(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("unexpected success: got=nil want=panic")
+		}
+	}()
+	errdecode.RegisterRange("ranges.overlap", 90900, 91100)
+}
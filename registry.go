@@ -0,0 +1,67 @@
+package errdecode
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnregistered is returned when a rule catalog references an error value
+// or matcher that has not been registered.
+var ErrUnregistered = errors.New("errdecode: unregistered name")
+
+var registry = struct {
+	sync.RWMutex
+	errors   map[string]error
+	matchers map[string]MatcherFunc
+}{
+	errors:   make(map[string]error),
+	matchers: make(map[string]MatcherFunc),
+}
+
+// RegisterError makes an error value available to rule catalogs under the
+// given name, e.g., "auth.invalid_token". It is typically called from an
+// init function of the package declaring the error.
+//
+// It panics if the name is registered twice.
+func RegisterError(name string, err error) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.errors[name]; dup {
+		panic("errdecode: RegisterError called twice for " + name)
+	}
+	registry.errors[name] = err
+}
+
+// RegisterMatcher makes a matcher available to rule catalogs under the given
+// ID, e.g., "pg.unique_violation".
+//
+// It panics if the ID is registered twice.
+func RegisterMatcher(id string, m MatcherFunc) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.matchers[id]; dup {
+		panic("errdecode: RegisterMatcher called twice for " + id)
+	}
+	registry.matchers[id] = m
+}
+
+func lookupError(name string) (error, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	err, ok := registry.errors[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: error %q", ErrUnregistered, name)
+	}
+	return err, nil
+}
+
+func lookupMatcher(id string) (MatcherFunc, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	m, ok := registry.matchers[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: matcher %q", ErrUnregistered, id)
+	}
+	return m, nil
+}
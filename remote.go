@@ -0,0 +1,153 @@
+package errdecode
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+// RemoteCatalog fetches a rule catalog from an HTTP(S) endpoint and refreshes
+// it periodically, so that many services can share one centrally managed
+// catalog.
+//
+// Refreshes are conditional on the ETag of the last response. If a refresh
+// fails, the last good catalog remains in use.
+type RemoteCatalog struct {
+	// URL is the catalog endpoint.
+	URL string
+
+	// Client is used to fetch the catalog, e.g., configured with custom TLS
+	// settings. It defaults to http.DefaultClient.
+	Client *http.Client
+
+	// Authorize is called on every request before it is sent, if set, e.g.,
+	// to add an authorization header.
+	Authorize func(*http.Request) error
+
+	// Interval is the refresh interval. It defaults to one minute.
+	Interval time.Duration
+
+	// Fallback is used if the catalog cannot be fetched initially, if set,
+	// e.g., a catalog embedded in the binary.
+	Fallback []Rule
+
+	// Options are applied to every decoder created from the catalog.
+	Options []Option
+
+	// OnReload receives a new decoder every time the catalog was loaded.
+	OnReload func(*Decoder)
+
+	// OnError receives errors encountered while refreshing the catalog, if
+	// set.
+	OnError func(error)
+
+	etag string
+}
+
+// Run fetches the catalog and refreshes it until ctx is done. It returns an
+// error if the catalog cannot be fetched initially and there is no fallback.
+func (rc *RemoteCatalog) Run(ctx context.Context) error {
+	if err := rc.refresh(ctx); err != nil {
+		if rc.Fallback == nil {
+			return err
+		}
+		rc.fail(err)
+		rc.reload(rc.Fallback)
+	}
+
+	interval := rc.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := rc.refresh(ctx); err != nil {
+			rc.fail(err)
+		}
+	}
+}
+
+// refresh fetches the catalog unless it has not been modified.
+func (rc *RemoteCatalog) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rc.URL, nil)
+	if err != nil {
+		return err
+	}
+	if rc.etag != "" {
+		req.Header.Set("If-None-Match", rc.etag)
+	}
+	if rc.Authorize != nil {
+		if err := rc.Authorize(req); err != nil {
+			return err
+		}
+	}
+
+	client := rc.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil
+	default:
+		return fmt.Errorf("errdecode: fetch catalog: unexpected status %s", resp.Status)
+	}
+
+	rules, err := loadCatalog(io.LimitReader(resp.Body, 10<<20), catalogExt(resp))
+	if err != nil {
+		return err
+	}
+	rc.etag = resp.Header.Get("ETag")
+	rc.reload(rules)
+	return nil
+}
+
+func (rc *RemoteCatalog) reload(rules []Rule) {
+	if rc.OnReload != nil {
+		rc.OnReload(New(rules, rc.Options...))
+	}
+}
+
+func (rc *RemoteCatalog) fail(err error) {
+	if rc.OnError != nil {
+		rc.OnError(err)
+	}
+}
+
+// catalogExt derives the catalog format from the content type of resp,
+// falling back to the extension of the requested path.
+func catalogExt(resp *http.Response) string {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		return ".json"
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return ".yaml"
+	case "application/toml":
+		return ".toml"
+	}
+	u := resp.Request.URL
+	if u == nil {
+		u = &url.URL{}
+	}
+	return path.Ext(u.Path)
+}
@@ -0,0 +1,101 @@
+package errdecode_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestRemoteCatalog(t *testing.T) {
+	var notModified atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"rules": [{"code": 1001, "message": "error.auth", "errors": ["catalog.invalid_token"]}]}`))
+	}))
+	defer srv.Close()
+
+	reloads := make(chan *errdecode.Decoder, 10)
+	rc := &errdecode.RemoteCatalog{
+		URL:      srv.URL + "/catalog",
+		Client:   srv.Client(),
+		Interval: 5 * time.Millisecond,
+		Authorize: func(r *http.Request) error {
+			r.Header.Set("Authorization", "Bearer secret")
+			return nil
+		},
+		OnReload: func(d *errdecode.Decoder) { reloads <- d },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- rc.Run(ctx) }()
+
+	select {
+	case d := <-reloads:
+		if msg := d.Translate(errCatalogToken).Error(); msg != "error.auth" {
+			t.Fatalf("unexpected message: got='%s'", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for catalog")
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); notModified.Load() < 2; {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for conditional refreshes")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if n := len(reloads); n != 0 {
+		t.Fatalf("expected unmodified catalog not to be reloaded, got %d reloads", n)
+	}
+}
+
+func TestRemoteCatalogFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rc := &errdecode.RemoteCatalog{URL: srv.URL + "/catalog.json", Client: srv.Client()}
+	if err := rc.Run(ctx); err == nil {
+		t.Fatalf("expected an error without fallback")
+	}
+
+	var reloaded *errdecode.Decoder
+	rc = &errdecode.RemoteCatalog{
+		URL:      srv.URL + "/catalog.json",
+		Client:   srv.Client(),
+		Fallback: []errdecode.Rule{{Code: 1001, Message: "error.fallback", Errors: []error{errCatalogToken}}},
+		OnReload: func(d *errdecode.Decoder) {
+			reloaded = d
+			cancel()
+		},
+	}
+	if err := rc.Run(ctx); err != context.Canceled {
+		t.Fatalf("unexpected error: got=%v want=%v", err, context.Canceled)
+	}
+	if msg := reloaded.Translate(errCatalogToken).Error(); msg != "error.fallback" {
+		t.Fatalf("unexpected message: got='%s'", msg)
+	}
+}
@@ -0,0 +1,80 @@
+package errdecode
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// ErrorReport describes a classified error handed to an ErrorReporter.
+type ErrorReport struct {
+	// Err is the classified error.
+	Err ClassifiedError
+
+	// Cause is the error that was translated.
+	Cause error
+
+	// Fingerprint groups reports by class rather than by cause, i.e.,
+	// {"errdecode", "<code>"}, so that errors of the same class are grouped
+	// into a single issue whatever their cause.
+	Fingerprint []string
+
+	// Tags are the metadata of the class: code, message_key, retryable,
+	// severity, namespace and correlation_id, the latter three being set
+	// only if known.
+	Tags map[string]string
+}
+
+// ErrorReporter receives classified errors, e.g., to report them to an error
+// tracker such as Sentry.
+type ErrorReporter interface {
+	ReportError(ctx context.Context, report ErrorReport)
+}
+
+// ErrorReporterFunc is a func satisfying the ErrorReporter interface.
+type ErrorReporterFunc func(ctx context.Context, report ErrorReport)
+
+// ReportError satisfies the ErrorReporter interface.
+func (fn ErrorReporterFunc) ReportError(ctx context.Context, report ErrorReport) {
+	fn(ctx, report)
+}
+
+// ReportErrors is used to hand translated errors of at least the given
+// severity to r, see OnTranslate. Unclassified errors are not reported, see
+// SampleUnclassified.
+func ReportErrors(r ErrorReporter, min Severity) Option {
+	return func(d *Decoder) {
+		d.onTranslate = append(d.onTranslate, func(ctx context.Context, err, translated error) {
+			var ce ClassifiedError
+			if !errors.As(translated, &ce) || ce.Severity() < min {
+				return
+			}
+			r.ReportError(ctx, d.errorReport(ctx, err, ce))
+		})
+		d.options = append(d.options, "ReportErrors")
+	}
+}
+
+func (d *Decoder) errorReport(ctx context.Context, err error, ce ClassifiedError) ErrorReport {
+	code := strconv.Itoa(ce.Code())
+	tags := map[string]string{
+		"code":        code,
+		"message_key": ce.MessageKey(),
+		"retryable":   strconv.FormatBool(ce.Retryable()),
+	}
+	if ce.Severity() != SeverityUnspecified {
+		tags["severity"] = ce.Severity().String()
+	}
+	if ns := d.idx.codeToRule[ce.Code()].Namespace; ns != "" {
+		tags["namespace"] = ns
+	}
+	if id, ok := CorrelationIDFromContext(ctx); ok {
+		tags["correlation_id"] = id
+	}
+	return ErrorReport{
+		Err:         ce,
+		Cause:       err,
+		Fingerprint: []string{"errdecode", code},
+		Tags:        tags,
+	}
+}
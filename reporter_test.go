@@ -0,0 +1,59 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestReportErrors(t *testing.T) {
+	var reports []errdecode.ErrorReport
+	r := errdecode.ErrorReporterFunc(func(_ context.Context, report errdecode.ErrorReport) {
+		reports = append(reports, report)
+	})
+	errdecode.RegisterRange("reporter.billing", 95000, 95099)
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 95001, Message: "error.client", Match: func(err error) bool { return errors.Is(err, errClient1) }, Severity: errdecode.SeverityError, Namespace: "reporter.billing", Retryable: true},
+		{Code: codeCustomError, Message: "error.custom", Errors: []error{errClient2}, Severity: errdecode.SeverityWarning},
+	}, errdecode.ReportErrors(r, errdecode.SeverityError))
+
+	ctx := errdecode.WithCorrelationID(context.Background(), "req-1")
+	causes := []error{fmt.Errorf("charge: %w", errClient1), fmt.Errorf("refund: %w", errClient1), errClient2, errUnclassified}
+	for _, err := range causes {
+		dec.TranslateContext(ctx, err)
+	}
+
+	if len(reports) != 2 {
+		t.Fatalf("unexpected reports: got=%d want=2", len(reports))
+	}
+	wantTags := map[string]string{
+		"code":           "95001",
+		"message_key":    "error.client",
+		"retryable":      "true",
+		"severity":       "error",
+		"namespace":      "reporter.billing",
+		"correlation_id": "req-1",
+	}
+	for i, report := range reports {
+		if report.Cause != causes[i] {
+			t.Fatalf("unexpected cause: got=%v want=%v", report.Cause, causes[i])
+		}
+		if report.Err.Code() != 95001 {
+			t.Fatalf("unexpected code: got=%d want=%d", report.Err.Code(), 95001)
+		}
+		if fp := fmt.Sprint(report.Fingerprint); fp != "[errdecode 95001]" {
+			t.Fatalf("unexpected fingerprint: got=%s want=%s", fp, "[errdecode 95001]")
+		}
+		if len(report.Tags) != len(wantTags) {
+			t.Fatalf("unexpected tags: got=%v want=%v", report.Tags, wantTags)
+		}
+		for k, v := range wantTags {
+			if report.Tags[k] != v {
+				t.Fatalf("unexpected tag %s: got=%s want=%s", k, report.Tags[k], v)
+			}
+		}
+	}
+}
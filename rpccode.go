@@ -0,0 +1,33 @@
+package errdecode
+
+import "net/http"
+
+// RPCCode returns the gRPC status code of the rule, as defined by
+// google.golang.org/grpc/codes and shared by Connect. It is GRPCCode if set,
+// or is derived from HTTPStatus after the google.rpc.Code mapping, or is 2
+// (Unknown).
+func (r Rule) RPCCode() int {
+	if r.GRPCCode != 0 {
+		return r.GRPCCode
+	}
+	if c, ok := httpToRPC[r.HTTPStatus]; ok {
+		return c
+	}
+	return 2 // Unknown
+}
+
+var httpToRPC = map[int]int{
+	http.StatusBadRequest:          3,  // InvalidArgument
+	http.StatusUnauthorized:        16, // Unauthenticated
+	http.StatusForbidden:           7,  // PermissionDenied
+	http.StatusNotFound:            5,  // NotFound
+	http.StatusConflict:            6,  // AlreadyExists
+	http.StatusPreconditionFailed:  9,  // FailedPrecondition
+	http.StatusRequestTimeout:      4,  // DeadlineExceeded
+	http.StatusTooManyRequests:     8,  // ResourceExhausted
+	499:                            1,  // Canceled, client closed request
+	http.StatusInternalServerError: 13, // Internal
+	http.StatusNotImplemented:      12, // Unimplemented
+	http.StatusServiceUnavailable:  14, // Unavailable
+	http.StatusGatewayTimeout:      4,  // DeadlineExceeded
+}
@@ -0,0 +1,29 @@
+package errdecode_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestRuleRPCCode(t *testing.T) {
+	tests := []struct {
+		name string
+		rule errdecode.Rule
+		want int
+	}{
+		{"explicit", errdecode.Rule{GRPCCode: 9, HTTPStatus: http.StatusNotFound}, 9},
+		{"from HTTP status", errdecode.Rule{HTTPStatus: http.StatusNotFound}, 5},
+		{"unmapped HTTP status", errdecode.Rule{HTTPStatus: http.StatusTeapot}, 2},
+		{"unspecified", errdecode.Rule{}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.RPCCode(); got != tt.want {
+				t.Fatalf("unexpected code: got=%d want=%d", got, tt.want)
+			}
+		})
+	}
+}
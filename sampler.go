@@ -0,0 +1,153 @@
+package errdecode
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Sample describes an unclassified error, as reported by a Sampler.
+type Sample struct {
+	// Type is the Go type of the error, e.g., "*fs.PathError".
+	Type string
+
+	// Message is the message of the error.
+	Message string
+
+	// Err is the first occurrence of the error in the batch.
+	Err error
+
+	// Count is the number of occurrences of the error in the batch.
+	Count int
+
+	// First and Last are the times of the first and last occurrences of the
+	// error in the batch.
+	First, Last time.Time
+}
+
+// Reporter receives the batches of unclassified errors collected by a
+// Sampler, e.g., to file them in an issue tracker.
+type Reporter interface {
+	Report(ctx context.Context, samples []Sample) error
+}
+
+// ReporterFunc is a func satisfying the Reporter interface.
+type ReporterFunc func(ctx context.Context, samples []Sample) error
+
+// Report satisfies the Reporter interface.
+func (fn ReporterFunc) Report(ctx context.Context, samples []Sample) error {
+	return fn(ctx, samples)
+}
+
+// Sampler collects the unclassified errors of decoders, see
+// SampleUnclassified, and periodically hands them to a Reporter, so that
+// missing rules are discovered in production.
+//
+// Errors are deduplicated by type and message, and at most MaxSamples
+// distinct errors are collected per batch, so that a burst of errors does not
+// flood the reporter.
+type Sampler struct {
+	// Reporter receives the batches of samples.
+	Reporter Reporter
+
+	// Interval is the reporting interval. It defaults to one minute.
+	Interval time.Duration
+
+	// MaxSamples is the maximum number of distinct errors per batch. Further
+	// errors are dropped until the next batch. It defaults to 100.
+	MaxSamples int
+
+	// OnError receives errors returned by the reporter, if set. Failed
+	// batches are not retried.
+	OnError func(error)
+
+	mu      sync.Mutex
+	pending []*Sample
+	index   map[sampleKey]*Sample
+}
+
+type sampleKey struct {
+	typ, msg string
+}
+
+// SampleUnclassified is used to collect the errors that could not be
+// classified into s, see OnTranslate.
+func SampleUnclassified(s *Sampler) Option {
+	return OnTranslate(func(_ context.Context, err, translated error) {
+		if _, ok := translated.(ClassifiedError); !ok && err != nil {
+			s.add(err)
+		}
+	})
+}
+
+func (s *Sampler) add(err error) {
+	now := time.Now()
+	key := sampleKey{fmt.Sprintf("%T", err), err.Error()}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sample, ok := s.index[key]; ok {
+		sample.Count++
+		sample.Last = now
+		return
+	}
+	max := s.MaxSamples
+	if max <= 0 {
+		max = 100
+	}
+	if len(s.pending) >= max {
+		return
+	}
+	if s.index == nil {
+		s.index = make(map[sampleKey]*Sample)
+	}
+	sample := &Sample{Type: key.typ, Message: key.msg, Err: err, Count: 1, First: now, Last: now}
+	s.index[key] = sample
+	s.pending = append(s.pending, sample)
+}
+
+// Flush hands the errors collected since the last batch to the reporter, if
+// any, and returns its error.
+func (s *Sampler) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending, s.index = nil, nil
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+	samples := make([]Sample, len(pending))
+	for i, sample := range pending {
+		samples[i] = *sample
+	}
+	return s.Reporter.Report(ctx, samples)
+}
+
+// Run reports the collected errors every interval until ctx is done, at which
+// point the last batch is flushed.
+func (s *Sampler) Run(ctx context.Context) error {
+	interval := s.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.fail(s.Flush(context.WithoutCancel(ctx)))
+			return ctx.Err()
+		case <-ticker.C:
+			s.fail(s.Flush(ctx))
+		}
+	}
+}
+
+func (s *Sampler) fail(err error) {
+	if err != nil && s.OnError != nil {
+		s.OnError(err)
+	}
+}
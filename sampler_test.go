@@ -0,0 +1,97 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestSampler(t *testing.T) {
+	var batches [][]errdecode.Sample
+	s := &errdecode.Sampler{
+		Reporter: errdecode.ReporterFunc(func(_ context.Context, samples []errdecode.Sample) error {
+			batches = append(batches, samples)
+			return nil
+		}),
+		MaxSamples: 2,
+	}
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+	}, errdecode.SampleUnclassified(s))
+
+	pathErr := &fs.PathError{Op: "open", Path: "config.json", Err: fs.ErrNotExist}
+	for _, err := range []error{
+		errUnclassified,
+		errClient1,
+		pathErr,
+		errors.New("unclassified error"),
+		&fs.PathError{Op: "open", Path: "config.json", Err: fs.ErrNotExist},
+		errors.New("dropped"),
+	} {
+		dec.Translate(err)
+	}
+
+	ctx := context.Background()
+	if err := s.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 1 {
+		t.Fatalf("unexpected batches: got=%d want=1", len(batches))
+	}
+
+	want := []errdecode.Sample{
+		{Type: "*errors.errorString", Message: "unclassified error", Err: errUnclassified, Count: 2},
+		{Type: "*fs.PathError", Message: pathErr.Error(), Err: pathErr, Count: 2},
+	}
+	got := batches[0]
+	if len(got) != len(want) {
+		t.Fatalf("unexpected samples: got=%v want=%v", got, want)
+	}
+	for i, sample := range got {
+		if sample.Type != want[i].Type || sample.Message != want[i].Message || sample.Err != want[i].Err || sample.Count != want[i].Count {
+			t.Fatalf("unexpected sample %d: got=%+v want=%+v", i, sample, want[i])
+		}
+		if sample.Last.Before(sample.First) {
+			t.Fatalf("unexpected times of sample %d: first=%v last=%v", i, sample.First, sample.Last)
+		}
+	}
+}
+
+func TestSamplerRun(t *testing.T) {
+	errReport := errors.New("tracker unavailable")
+	reports := make(chan []errdecode.Sample, 1)
+	failures := make(chan error, 1)
+	s := &errdecode.Sampler{
+		Reporter: errdecode.ReporterFunc(func(_ context.Context, samples []errdecode.Sample) error {
+			reports <- samples
+			return errReport
+		}),
+		Interval: time.Hour,
+		OnError:  func(err error) { failures <- err },
+	}
+	dec := errdecode.New(nil, errdecode.SampleUnclassified(s))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	dec.Translate(os.ErrNotExist)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: got=%v want=%v", err, context.Canceled)
+	}
+	if samples := <-reports; len(samples) != 1 || samples[0].Err != os.ErrNotExist {
+		t.Fatalf("unexpected samples: got=%v", samples)
+	}
+	if err := <-failures; err != errReport {
+		t.Fatalf("unexpected failure: got=%v want=%v", err, errReport)
+	}
+}
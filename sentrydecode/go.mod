@@ -0,0 +1,15 @@
+module github.com/iamrgon/errdecode/sentrydecode
+
+go 1.21
+
+require (
+	github.com/getsentry/sentry-go v0.29.1
+	github.com/iamrgon/errdecode v0.1.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
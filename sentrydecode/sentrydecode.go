@@ -0,0 +1,65 @@
+// Package sentrydecode reports the errors classified by an errdecode.Decoder
+// to Sentry:
+//
+//	decoder := errdecode.New(rules, sentrydecode.ReportErrors(errdecode.SeverityError))
+//	err = decoder.TranslateContext(ctx, err)
+//
+// Events are fingerprinted by code, so that errors of the same class are
+// grouped into a single issue whatever their cause, see errdecode.ErrorReport.
+package sentrydecode
+
+import (
+	"context"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/iamrgon/errdecode"
+)
+
+// ReportErrors is used to report translated errors of at least the given
+// severity with Reporter(nil), see errdecode.ReportErrors.
+func ReportErrors(min errdecode.Severity) errdecode.Option {
+	return errdecode.ReportErrors(Reporter(nil), min)
+}
+
+// Reporter returns an errdecode.ErrorReporter capturing the cause of each
+// report as an exception event of hub, with the fingerprint and tags of the
+// report. The translated message and fields are attached as the "errdecode"
+// context of the event.
+//
+// If hub is nil, the hub of the context passed to TranslateContext is used,
+// falling back to the current hub.
+func Reporter(hub *sentry.Hub) errdecode.ErrorReporter {
+	return errdecode.ErrorReporterFunc(func(ctx context.Context, report errdecode.ErrorReport) {
+		h := hub
+		if h == nil {
+			h = sentry.GetHubFromContext(ctx)
+		}
+		if h == nil {
+			h = sentry.CurrentHub()
+		}
+
+		h.WithScope(func(scope *sentry.Scope) {
+			scope.SetFingerprint(report.Fingerprint)
+			scope.SetTags(report.Tags)
+			scope.SetLevel(level(report.Err.Severity()))
+			c := sentry.Context{"message": report.Err.Error()}
+			if fields := report.Err.Fields(); len(fields) > 0 {
+				c["fields"] = fields
+			}
+			scope.SetContext("errdecode", c)
+			h.CaptureException(report.Cause)
+		})
+	})
+}
+
+func level(s errdecode.Severity) sentry.Level {
+	switch s {
+	case errdecode.SeverityInfo:
+		return sentry.LevelInfo
+	case errdecode.SeverityWarning:
+		return sentry.LevelWarning
+	case errdecode.SeverityCritical:
+		return sentry.LevelFatal
+	}
+	return sentry.LevelError
+}
@@ -0,0 +1,58 @@
+package sentrydecode_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/sentrydecode"
+)
+
+var errDatabase = errors.New("database unavailable")
+
+func TestReporter(t *testing.T) {
+	var events []*sentry.Event
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		BeforeSend: func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+			events = append(events, event)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hub := sentry.NewHub(client, sentry.NewScope())
+
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Try again later.", Match: func(err error) bool { return errors.Is(err, errDatabase) }, Severity: errdecode.SeverityCritical},
+	}, errdecode.ReportErrors(sentrydecode.Reporter(nil), errdecode.SeverityError))
+
+	ctx := sentry.SetHubOnContext(context.Background(), hub)
+	dec.TranslateContext(ctx, fmt.Errorf("charge: %w", errDatabase))
+	dec.TranslateContext(ctx, fmt.Errorf("refund: %w", errDatabase))
+	dec.TranslateContext(ctx, errors.New("unclassified"))
+
+	if len(events) != 2 {
+		t.Fatalf("unexpected events: got=%d want=2", len(events))
+	}
+	for _, event := range events {
+		if fp := fmt.Sprint(event.Fingerprint); fp != "[errdecode 1001]" {
+			t.Fatalf("unexpected fingerprint: got=%s want=%s", fp, "[errdecode 1001]")
+		}
+		if event.Level != sentry.LevelFatal {
+			t.Fatalf("unexpected level: got=%s want=%s", event.Level, sentry.LevelFatal)
+		}
+		if event.Tags["code"] != "1001" || event.Tags["severity"] != "critical" {
+			t.Fatalf("unexpected tags: got=%v", event.Tags)
+		}
+		if msg := event.Contexts["errdecode"]["message"]; msg != "Try again later." {
+			t.Fatalf("unexpected message: got=%v want=%s", msg, "Try again later.")
+		}
+	}
+	if got := events[0].Exception[len(events[0].Exception)-1].Value; got != "charge: database unavailable" {
+		t.Fatalf("unexpected exception: got=%s want=%s", got, "charge: database unavailable")
+	}
+}
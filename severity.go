@@ -0,0 +1,48 @@
+package errdecode
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Severity describes how serious a class of errors is, e.g., to decide
+// whether it should page an operator.
+type Severity int
+
+// Severity levels, in increasing order of seriousness.
+const (
+	SeverityUnspecified Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+var severityNames = [...]string{
+	SeverityUnspecified: "",
+	SeverityInfo:        "info",
+	SeverityWarning:     "warning",
+	SeverityError:       "error",
+	SeverityCritical:    "critical",
+}
+
+// String returns the lower-case name of the severity, or an empty string if
+// unspecified.
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return "severity(" + strconv.Itoa(int(s)) + ")"
+	}
+	return severityNames[s]
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface. It accepts
+// the names returned by String.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for i, name := range severityNames {
+		if name == string(text) {
+			*s = Severity(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("errdecode: unknown severity %q", text)
+}
@@ -0,0 +1,99 @@
+package errdecode
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// LogValue satisfies the slog.LogValuer interface, so that logging a
+// classified error emits a group with its code, message, severity and cause.
+func (e *matchedError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Int("code", e.code),
+		slog.String("message", e.message()),
+	}
+	if e.severity != SeverityUnspecified {
+		attrs = append(attrs, slog.String("severity", e.severity.String()))
+	}
+	if e.err != nil {
+		attrs = append(attrs, slog.String("cause", e.err.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogOption configures the translations logged by LogWith.
+type LogOption func(*logConfig)
+
+type logConfig struct {
+	unclassifiedOnly bool
+	minSeverity      Severity
+}
+
+// LogUnclassified is used to only log unclassified errors, e.g., to find
+// errors missing a rule.
+func LogUnclassified() LogOption {
+	return func(c *logConfig) { c.unclassifiedOnly = true }
+}
+
+// LogMinSeverity is used to only log classified errors of at least the given
+// severity, along with unclassified errors.
+func LogMinSeverity(s Severity) LogOption {
+	return func(c *logConfig) { c.minSeverity = s }
+}
+
+// LogWith is used to log every translation with logger, so that errors are
+// logged consistently whatever the call site, see OnTranslate. Records have
+// the following attributes:
+//
+//   - code: the code of the classification, 0 for unclassified errors
+//   - message_key: the message of the rule, before translation
+//   - severity: the severity of the rule, if any
+//   - cause: the message of the translated error
+//   - correlation_id: the correlation ID of the context, if any, see
+//     WithCorrelationID
+//
+// Unclassified errors, and errors of severity error or critical, are logged
+// at the error level, errors of severity warning at the warning level and
+// other errors at the info level.
+func LogWith(logger *slog.Logger, options ...LogOption) Option {
+	var c logConfig
+	for _, option := range options {
+		option(&c)
+	}
+	return OnTranslate(func(ctx context.Context, err, translated error) {
+		var ce ClassifiedError
+		classified := errors.As(translated, &ce)
+		if classified && (c.unclassifiedOnly || ce.Severity() < c.minSeverity) {
+			return
+		}
+
+		level, msg := slog.LevelError, "errdecode: unclassified error"
+		attrs := make([]slog.Attr, 0, 5)
+		if classified {
+			msg = "errdecode: classified error"
+			level = severityLevel(ce.Severity())
+			attrs = append(attrs, slog.Int("code", ce.Code()), slog.String("message_key", ce.MessageKey()))
+			if ce.Severity() != SeverityUnspecified {
+				attrs = append(attrs, slog.String("severity", ce.Severity().String()))
+			}
+		} else {
+			attrs = append(attrs, slog.Int("code", 0))
+		}
+		attrs = append(attrs, slog.String("cause", err.Error()))
+		if id, ok := CorrelationIDFromContext(ctx); ok {
+			attrs = append(attrs, slog.String("correlation_id", id))
+		}
+		logger.LogAttrs(ctx, level, msg, attrs...)
+	})
+}
+
+func severityLevel(s Severity) slog.Level {
+	switch {
+	case s >= SeverityError:
+		return slog.LevelError
+	case s == SeverityWarning:
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
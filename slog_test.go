@@ -0,0 +1,149 @@
+package errdecode_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestClassifiedErrorLogValue(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "error.client", Errors: []error{errClient1}, Severity: errdecode.SeverityWarning},
+		{Code: 1002, Message: "error.other", Errors: []error{errClient2}},
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		want map[string]interface{}
+	}{
+		{
+			"with severity", dec.Translate(errClient1),
+			map[string]interface{}{"code": 1001.0, "message": "error.client", "severity": "warning", "cause": "client error 1"},
+		},
+		{
+			"without severity", dec.Translate(errClient2),
+			map[string]interface{}{"code": 1002.0, "message": "error.other", "cause": "client error 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			slog.New(slog.NewJSONHandler(&buf, nil)).Error("request failed", "err", tt.err)
+
+			var record struct {
+				Err map[string]interface{} `json:"err"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(record.Err) != len(tt.want) {
+				t.Fatalf("unexpected attributes: got=%v want=%v", record.Err, tt.want)
+			}
+			for k, v := range tt.want {
+				if record.Err[k] != v {
+					t.Fatalf("unexpected attribute %s: got=%v want=%v", k, record.Err[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	tests := []struct {
+		severity errdecode.Severity
+		want     string
+	}{
+		{errdecode.SeverityUnspecified, ""},
+		{errdecode.SeverityInfo, "info"},
+		{errdecode.SeverityCritical, "critical"},
+		{errdecode.Severity(42), "severity(42)"},
+	}
+	for _, tt := range tests {
+		if got := tt.severity.String(); got != tt.want {
+			t.Fatalf("unexpected name: got='%s' want='%s'", got, tt.want)
+		}
+	}
+}
+
+func TestLogWith(t *testing.T) {
+	rules := []errdecode.Rule{
+		{Code: 1001, Message: "error.client", Errors: []error{errClient1}, Severity: errdecode.SeverityWarning},
+		{Code: 1002, Message: "error.other", Errors: []error{errClient2}, Severity: errdecode.SeverityCritical},
+	}
+	errUnknown := errors.New("boom")
+
+	tests := []struct {
+		name    string
+		options []errdecode.LogOption
+		want    []map[string]interface{}
+	}{
+		{
+			"every translation", nil,
+			[]map[string]interface{}{
+				{"level": "WARN", "msg": "errdecode: classified error", "code": 1001.0, "message_key": "error.client", "severity": "warning", "cause": "client error 1", "correlation_id": "req-1"},
+				{"level": "ERROR", "msg": "errdecode: classified error", "code": 1002.0, "message_key": "error.other", "severity": "critical", "cause": "client error 2", "correlation_id": "req-1"},
+				{"level": "ERROR", "msg": "errdecode: unclassified error", "code": 0.0, "cause": "boom", "correlation_id": "req-1"},
+			},
+		},
+		{
+			"min severity", []errdecode.LogOption{errdecode.LogMinSeverity(errdecode.SeverityError)},
+			[]map[string]interface{}{
+				{"code": 1002.0},
+				{"code": 0.0},
+			},
+		},
+		{
+			"unclassified", []errdecode.LogOption{errdecode.LogUnclassified()},
+			[]map[string]interface{}{
+				{"code": 0.0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+			dec := errdecode.New(rules, errdecode.LogWith(logger, tt.options...))
+
+			ctx := errdecode.WithCorrelationID(context.Background(), "req-1")
+			for _, err := range []error{errClient1, errClient2, errUnknown} {
+				dec.TranslateContext(ctx, err)
+			}
+
+			records := json.NewDecoder(&buf)
+			for i, want := range tt.want {
+				var record map[string]interface{}
+				if err := records.Decode(&record); err != nil {
+					t.Fatalf("unexpected error decoding record %d: %v", i, err)
+				}
+				for k, v := range want {
+					if record[k] != v {
+						t.Fatalf("unexpected attribute %s of record %d: got=%v want=%v", k, i, record[k], v)
+					}
+				}
+			}
+			if records.More() {
+				t.Fatalf("unexpected records")
+			}
+		})
+	}
+}
+
+func TestLogWithNil(t *testing.T) {
+	var buf bytes.Buffer
+	dec := errdecode.New(nil, errdecode.LogWith(slog.New(slog.NewJSONHandler(&buf, nil))))
+	if err := dec.Translate(nil); err != nil {
+		t.Fatalf("unexpected error: got=%v want=nil", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected records: %s", buf.String())
+	}
+}
@@ -0,0 +1,84 @@
+package errdecode
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Stats describes how often errors have been classified by a decoder.
+type Stats struct {
+	// Hits is the number of translated errors per code.
+	Hits map[int]uint64
+
+	// Unclassified is the number of translated errors that could not be
+	// classified.
+	Unclassified uint64
+}
+
+// Stats returns a snapshot of the classification counts since the decoder was
+// created. Every error returned by Translate, TranslateContext and
+// TranslateAll, and every call to TranslateCode, is counted once.
+func (d *Decoder) Stats() Stats {
+	return d.stats.snapshot()
+}
+
+// stats counts classifications. Counters for the codes of the rule set are
+// allocated upfront, so they can be incremented without locking.
+type stats struct {
+	known        map[int]*atomic.Uint64
+	unclassified atomic.Uint64
+
+	mu      sync.Mutex
+	unknown map[int]uint64 // codes returned by custom encoders
+}
+
+func newStats(idx *ruleIndex) *stats {
+	s := &stats{known: make(map[int]*atomic.Uint64, len(idx.codeToRule))}
+	for code := range idx.codeToRule {
+		s.known[code] = new(atomic.Uint64)
+	}
+	return s
+}
+
+// record counts a translated error.
+func (s *stats) record(err error) {
+	if err == nil {
+		return
+	}
+	if ce, ok := err.(ClassifiedError); ok {
+		s.recordCode(ce.Code(), true)
+		return
+	}
+	s.recordCode(0, false)
+}
+
+// recordCode counts a classification.
+func (s *stats) recordCode(code int, classified bool) {
+	if !classified {
+		s.unclassified.Add(1)
+		return
+	}
+	if n, ok := s.known[code]; ok {
+		n.Add(1)
+		return
+	}
+	s.mu.Lock()
+	if s.unknown == nil {
+		s.unknown = make(map[int]uint64)
+	}
+	s.unknown[code]++
+	s.mu.Unlock()
+}
+
+func (s *stats) snapshot() Stats {
+	hits := make(map[int]uint64, len(s.known))
+	for code, n := range s.known {
+		hits[code] = n.Load()
+	}
+	s.mu.Lock()
+	for code, n := range s.unknown {
+		hits[code] += n
+	}
+	s.mu.Unlock()
+	return Stats{Hits: hits, Unclassified: s.unclassified.Load()}
+}
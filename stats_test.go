@@ -0,0 +1,61 @@
+package errdecode_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestDecoderStats(t *testing.T) {
+	dec := newDecoder()
+	dec.Translate(errClient1)
+	dec.Translate(errClient2)
+	dec.Translate(errors.New("unmatched"))
+	dec.TranslateAll(errors.Join(errClient1, newCustomError("custom"), errors.New("unmatched")))
+
+	stats := dec.Stats()
+	want := map[int]uint64{codeClientError: 3, codeCustomError: 1, codeWrappedError: 0}
+	for code, n := range want {
+		if got := stats.Hits[code]; got != n {
+			t.Fatalf("unexpected hits for %d: got=%d want=%d", code, got, n)
+		}
+	}
+	if len(stats.Hits) != len(want) {
+		t.Fatalf("unexpected codes: %v", stats.Hits)
+	}
+	if stats.Unclassified != 2 {
+		t.Fatalf("unexpected unclassified count: got=%d want=2", stats.Unclassified)
+	}
+}
+
+func TestDecoderStatsCustomEncoder(t *testing.T) {
+	dec := errdecode.New(nil, errdecode.Encoder(func(err error) (int, string) { return codeCatchAll, "error.catchall" }))
+	dec.Translate(errClient1)
+	dec.Translate(errClient2)
+
+	if got := dec.Stats().Hits[codeCatchAll]; got != 2 {
+		t.Fatalf("unexpected hits: got=%d want=2", got)
+	}
+}
+
+func TestDecoderStatsJoined(t *testing.T) {
+	errJoined := errors.Join(errClient1, errUnclassified)
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: codeClientError, Message: "error.client", Errors: []error{errClient1}},
+		{Code: codeCatchAll, Message: "error.catchall", Match: func(err error) bool { return errors.Is(err, errUnclassified) }},
+	})
+	dec.Translate(errJoined)
+	dec.TranslateAll(errJoined)
+
+	stats := dec.Stats()
+	want := map[int]uint64{codeClientError: 2, codeCatchAll: 1}
+	for code, n := range want {
+		if got := stats.Hits[code]; got != n {
+			t.Fatalf("unexpected hits for %d: got=%d want=%d", code, got, n)
+		}
+	}
+	if stats.Unclassified != 0 {
+		t.Fatalf("unexpected unclassified count: got=%d want=0", stats.Unclassified)
+	}
+}
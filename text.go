@@ -0,0 +1,52 @@
+package errdecode
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidClassification is returned when parsing a malformed compact
+// classification.
+var ErrInvalidClassification = errors.New("errdecode: invalid classification")
+
+// Classification is the compact text form of a classified error, i.e., its
+// code and message key, e.g., "1001:error.auth".
+//
+// It implements encoding.TextMarshaler and encoding.TextUnmarshaler, so it can
+// be used in structured logs, URLs and flags, e.g., with flag.TextVar.
+type Classification struct {
+	Code       int
+	MessageKey string
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (c Classification) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (c *Classification) UnmarshalText(text []byte) error {
+	code, key, ok := strings.Cut(string(text), ":")
+	if !ok {
+		return fmt.Errorf("%w: missing separator in %q", ErrInvalidClassification, text)
+	}
+	n, err := strconv.Atoi(code)
+	if err != nil {
+		return fmt.Errorf("%w: invalid code in %q", ErrInvalidClassification, text)
+	}
+	c.Code, c.MessageKey = n, key
+	return nil
+}
+
+// String returns the compact text form.
+func (c Classification) String() string {
+	return strconv.Itoa(c.Code) + ":" + c.MessageKey
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface, see
+// Classification.
+func (e *matchedError) MarshalText() ([]byte, error) {
+	return Classification{e.code, e.key}.MarshalText()
+}
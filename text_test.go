@@ -0,0 +1,55 @@
+package errdecode_test
+
+import (
+	"encoding"
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestClassifiedErrorMarshalText(t *testing.T) {
+	err := newDecoder().Translate(errClient1)
+	m, ok := err.(encoding.TextMarshaler)
+	if !ok {
+		t.Fatalf("expected classified error to implement encoding.TextMarshaler")
+	}
+	text, mErr := m.MarshalText()
+	if mErr != nil {
+		t.Fatalf("unexpected error: %v", mErr)
+	}
+	if want := "1001:error.client"; string(text) != want {
+		t.Fatalf("unexpected text: got='%s' want='%s'", text, want)
+	}
+}
+
+func TestClassificationUnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    errdecode.Classification
+		wantErr error
+	}{
+		{"code and key", "1001:error.auth", errdecode.Classification{Code: 1001, MessageKey: "error.auth"}, nil},
+		{"key with separator", "1001:error:auth", errdecode.Classification{Code: 1001, MessageKey: "error:auth"}, nil},
+		{"empty key", "1001:", errdecode.Classification{Code: 1001}, nil},
+		{"missing separator", "1001", errdecode.Classification{}, errdecode.ErrInvalidClassification},
+		{"invalid code", "auth:error.auth", errdecode.Classification{}, errdecode.ErrInvalidClassification},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c errdecode.Classification
+			err := c.UnmarshalText([]byte(tt.text))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: got=%v want=%v", err, tt.wantErr)
+			}
+			if c != tt.want {
+				t.Fatalf("unexpected classification: got=%+v want=%+v", c, tt.want)
+			}
+			if err == nil && c.String() != tt.text {
+				t.Fatalf("expected text to round-trip: got='%s' want='%s'", c.String(), tt.text)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package errdecode
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Trace is a flight recorder for the classification decisions made while
+// serving a single request. It is safe for concurrent use.
+//
+// Tracing is opt-in: attach a trace to the request context with WithTrace and
+// translate errors with TranslateContext, then inspect Events once the
+// request has completed.
+type Trace struct {
+	mu     sync.Mutex
+	events []TraceEvent
+}
+
+// TraceEvent describes a single classification decision.
+type TraceEvent struct {
+	// Start is the time the translation started.
+	Start time.Time
+
+	// Duration is the time spent classifying and translating the error.
+	Duration time.Duration
+
+	// Err is the encountered error value.
+	Err error
+
+	// Code is the classification, or zero if the error was unclassified.
+	Code int
+
+	// Message is the message produced by the encoder, before translation.
+	Message string
+}
+
+// Events returns the recorded decisions in the order they were made.
+func (t *Trace) Events() []TraceEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	events := make([]TraceEvent, len(t.events))
+	copy(events, t.events)
+	return events
+}
+
+func (t *Trace) record(ev TraceEvent) {
+	t.mu.Lock()
+	t.events = append(t.events, ev)
+	t.mu.Unlock()
+}
+
+type traceKey struct{}
+
+// WithTrace returns a copy of ctx that records every classification made
+// through TranslateContext into the returned trace.
+func WithTrace(ctx context.Context) (context.Context, *Trace) {
+	t := &Trace{}
+	return context.WithValue(ctx, traceKey{}, t), t
+}
+
+// TraceFromContext returns the trace attached to ctx, if any.
+func TraceFromContext(ctx context.Context) (*Trace, bool) {
+	t, ok := ctx.Value(traceKey{}).(*Trace)
+	return t, ok
+}
@@ -0,0 +1,43 @@
+package errdecode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestTranslateContextTrace(t *testing.T) {
+	dec := newDecoder()
+	ctx, trace := errdecode.WithTrace(context.Background())
+
+	errUnmatched := errors.New("unmatched")
+	dec.TranslateContext(ctx, errClient1)
+	dec.TranslateContext(ctx, errUnmatched)
+
+	events := trace.Events()
+	if len(events) != 2 {
+		t.Fatalf("unexpected number of events: got=%d want=2", len(events))
+	}
+	if ev := events[0]; ev.Err != errClient1 || ev.Code != codeClientError || ev.Message != "error.client" {
+		t.Fatalf("unexpected classified event: %+v", ev)
+	}
+	if ev := events[1]; ev.Err != errUnmatched || ev.Code != 0 {
+		t.Fatalf("unexpected unclassified event: %+v", ev)
+	}
+	if events[0].Start.IsZero() || events[1].Start.Before(events[0].Start) {
+		t.Fatalf("expected events to be timed in order")
+	}
+}
+
+func TestTranslateContextWithoutTrace(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := errdecode.TraceFromContext(ctx); ok {
+		t.Fatalf("expected no trace to be attached")
+	}
+	err := newDecoder().TranslateContext(ctx, errClient1)
+	if msg := err.Error(); msg != "error.client" {
+		t.Fatalf("unexpected message: got='%s' want='error.client'", msg)
+	}
+}
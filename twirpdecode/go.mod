@@ -0,0 +1,14 @@
+module github.com/iamrgon/errdecode/twirpdecode
+
+go 1.21
+
+require (
+	github.com/iamrgon/errdecode v0.1.0
+	github.com/twitchtv/twirp v8.1.3+incompatible
+)
+
+require (
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
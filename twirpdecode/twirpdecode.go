@@ -0,0 +1,114 @@
+// Package twirpdecode converts errors classified by an errdecode.Decoder to
+// and from Twirp errors.
+//
+// Servers translate the errors returned by their methods with Interceptor:
+//
+//	handler := orders.NewOrderServiceServer(svc,
+//		twirp.WithServerInterceptors(twirpdecode.Interceptor(decoder)),
+//	)
+//
+// Clients turn the Twirp errors they receive back into classified errors with
+// FromTwirp.
+package twirpdecode
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/twitchtv/twirp"
+)
+
+// Meta keys of Twirp errors built from classified errors.
+const (
+	CodeMeta    = "errdecode_code"
+	DocsURLMeta = "docs_url"
+)
+
+// Interceptor returns a server interceptor translating the errors returned
+// by methods with d, see ToTwirp.
+func Interceptor(d *errdecode.Decoder) twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			resp, err := next(ctx, req)
+			if err != nil {
+				return resp, ToTwirp(ctx, d, err)
+			}
+			return resp, nil
+		}
+	}
+}
+
+// ToTwirp translates err with d and returns it as a Twirp error.
+//
+// The Twirp code is derived from the code of the rule, see
+// errdecode.Rule.RPCCode, and the message is the translated message. The code
+// of the rule and its DocsURL are added as CodeMeta and DocsURLMeta meta
+// entries.
+//
+// Unclassified Twirp errors are returned as-is. Other unclassified errors are
+// reported as twirp.Internal with a generic message, so their message never
+// reaches clients.
+func ToTwirp(ctx context.Context, d *errdecode.Decoder, err error) twirp.Error {
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) && !errors.As(d.TranslateContext(ctx, err), &ce) {
+		var twerr twirp.Error
+		if errors.As(err, &twerr) {
+			return twerr
+		}
+		return twirp.NewError(twirp.Internal, "internal error")
+	}
+
+	rule, _ := d.RuleFor(ce.Code())
+	twerr := twirp.NewError(rpcToTwirp[rule.RPCCode()], ce.Error()).
+		WithMeta(CodeMeta, strconv.Itoa(ce.Code()))
+	if rule.DocsURL != "" {
+		twerr = twerr.WithMeta(DocsURLMeta, rule.DocsURL)
+	}
+	return twerr
+}
+
+// FromTwirp returns a classified error for a Twirp error carrying a code in
+// its CodeMeta meta entry, e.g., as received by a client. Other errors are
+// returned as-is.
+//
+// If d has a rule for the code, the error is classified by the rule, so the
+// message is translated on the client side, and wraps the Twirp error.
+// Otherwise, the message of the Twirp error is used as-is.
+func FromTwirp(d *errdecode.Decoder, err error) error {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		return err
+	}
+	code, convErr := strconv.Atoi(twerr.Meta(CodeMeta))
+	if convErr != nil || code == 0 {
+		return err
+	}
+	if d != nil {
+		if _, ok := d.RuleFor(code); ok {
+			return d.WrapError(code, err)
+		}
+	}
+	return errdecode.Classify(code, twerr.Msg())
+}
+
+// rpcToTwirp maps gRPC codes to Twirp codes, which share their semantics.
+var rpcToTwirp = map[int]twirp.ErrorCode{
+	1:  twirp.Canceled,
+	2:  twirp.Unknown,
+	3:  twirp.InvalidArgument,
+	4:  twirp.DeadlineExceeded,
+	5:  twirp.NotFound,
+	6:  twirp.AlreadyExists,
+	7:  twirp.PermissionDenied,
+	8:  twirp.ResourceExhausted,
+	9:  twirp.FailedPrecondition,
+	10: twirp.Aborted,
+	11: twirp.OutOfRange,
+	12: twirp.Unimplemented,
+	13: twirp.Internal,
+	14: twirp.Unavailable,
+	15: twirp.DataLoss,
+	16: twirp.Unauthenticated,
+}
@@ -0,0 +1,100 @@
+package twirpdecode_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/twirpdecode"
+	"github.com/twitchtv/twirp"
+)
+
+var (
+	errNotFound  = errors.New("order not found")
+	errUnhandled = errors.New("connection reset by peer")
+)
+
+func newDecoder() *errdecode.Decoder {
+	return errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Order not found.", Errors: []error{errNotFound}, HTTPStatus: http.StatusNotFound, DocsURL: "https://docs.example.com/errors/1001"},
+	})
+}
+
+func TestToTwirp(t *testing.T) {
+	dec := newDecoder()
+
+	tests := []struct {
+		name         string
+		err          error
+		wantCode     twirp.ErrorCode
+		wantMsg      string
+		wantCodeMeta string
+		wantDocsURL  string
+	}{
+		{"classified", errNotFound, twirp.NotFound, "Order not found.", "1001", "https://docs.example.com/errors/1001"},
+		{"unclassified", errUnhandled, twirp.Internal, "internal error", "", ""},
+		{"twirp error", twirp.NewError(twirp.Unavailable, "downstream"), twirp.Unavailable, "downstream", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			twerr := twirpdecode.ToTwirp(context.Background(), dec, tt.err)
+			if twerr.Code() != tt.wantCode || twerr.Msg() != tt.wantMsg {
+				t.Fatalf("unexpected error: got=%s %q want=%s %q", twerr.Code(), twerr.Msg(), tt.wantCode, tt.wantMsg)
+			}
+			if got := twerr.Meta(twirpdecode.CodeMeta); got != tt.wantCodeMeta {
+				t.Fatalf("unexpected code meta: got=%q want=%q", got, tt.wantCodeMeta)
+			}
+			if got := twerr.Meta(twirpdecode.DocsURLMeta); got != tt.wantDocsURL {
+				t.Fatalf("unexpected docs URL meta: got=%q want=%q", got, tt.wantDocsURL)
+			}
+		})
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	method := twirpdecode.Interceptor(newDecoder())(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errNotFound
+	})
+	_, err := method(context.Background(), nil)
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) || twerr.Code() != twirp.NotFound {
+		t.Fatalf("unexpected error: got=%v want=%s", err, twirp.NotFound)
+	}
+}
+
+func TestFromTwirp(t *testing.T) {
+	dec := errdecode.New([]errdecode.Rule{
+		{Code: 1001, Message: "Commande introuvable."},
+	})
+	received := twirp.NewError(twirp.NotFound, "Order not found.").WithMeta(twirpdecode.CodeMeta, "1001")
+
+	tests := []struct {
+		name      string
+		dec       *errdecode.Decoder
+		err       error
+		wantCode  int
+		wantMsg   string
+		wantCause bool
+	}{
+		{"known code", dec, received, 1001, "Commande introuvable.", true},
+		{"no decoder", nil, received, 1001, "Order not found.", false},
+		{"unknown code", dec, twirp.NewError(twirp.NotFound, "Gone.").WithMeta(twirpdecode.CodeMeta, "1002"), 1002, "Gone.", false},
+		{"no code", dec, twirp.NewError(twirp.Internal, "internal error"), 0, "twirp error internal: internal error", false},
+		{"not twirp", dec, errUnhandled, 0, "connection reset by peer", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := twirpdecode.FromTwirp(tt.dec, tt.err)
+			if errdecode.Code(err) != tt.wantCode || err.Error() != tt.wantMsg {
+				t.Fatalf("unexpected error: got=%d %q want=%d %q", errdecode.Code(err), err.Error(), tt.wantCode, tt.wantMsg)
+			}
+			if tt.wantCause && !errors.Is(err, tt.err) {
+				t.Fatalf("expected the Twirp error to be wrapped")
+			}
+		})
+	}
+}
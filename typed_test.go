@@ -0,0 +1,57 @@
+package errdecode_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestTypedRules(t *testing.T) {
+	var customEvals int
+	dec := errdecode.New([]errdecode.Rule{
+		{
+			Code:    1001,
+			Message: "error.invalid_byte",
+			Types:   []reflect.Type{reflect.TypeOf(hex.InvalidByteError(0))},
+		},
+		{
+			Code:    1002,
+			Message: "error.custom",
+			Types:   []reflect.Type{reflect.TypeOf((*CustomError)(nil))},
+			Match: func(err error) bool {
+				customEvals++
+				var ce *CustomError
+				return errors.As(err, &ce) && *ce != "ignored"
+			},
+		},
+		{Code: codeCatchAll, Message: "error.catchall", Match: func(_ error) bool { return true }},
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"type without matcher", hex.InvalidByteError('g'), "error.invalid_byte"},
+		{"wrapped type", fmt.Errorf("decode: %w", hex.InvalidByteError('g')), "error.invalid_byte"},
+		{"type with matcher", newCustomError("custom"), "error.custom"},
+		{"rejected type falls back to generic matchers", newCustomError("ignored"), "error.catchall"},
+		{"unknown type uses generic matchers", errors.New("other"), "error.catchall"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if msg := dec.Translate(tt.err).Error(); msg != tt.want {
+				t.Fatalf("unexpected message: got='%s' want='%s'", msg, tt.want)
+			}
+		})
+	}
+
+	if customEvals != 2 {
+		t.Fatalf("expected typed matcher to run for its type only: got=%d want=2", customEvals)
+	}
+}
@@ -0,0 +1,163 @@
+package errdecode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Watcher reloads a rule catalog file whenever it changes, so message wording
+// and new codes can be rolled out by updating a mounted config file without
+// restarting the service.
+//
+// Changes are detected by polling the modification time and size of the file.
+// The format of the file is chosen by its extension, see LoadFS.
+type Watcher struct {
+	// Path is the catalog file to watch.
+	Path string
+
+	// Interval is the polling interval. It defaults to one second.
+	Interval time.Duration
+
+	// Options are applied to every decoder created from the catalog.
+	Options []Option
+
+	// OnReload receives a new decoder every time the catalog was loaded.
+	OnReload func(*Decoder)
+
+	// OnError receives errors encountered while reloading the catalog, if
+	// set. The last good decoder remains in use until the file is fixed.
+	OnError func(error)
+}
+
+// Watch calls onReload with a decoder for the catalog at path, and again
+// every time the file changes, until ctx is done. See Watcher.
+func Watch(ctx context.Context, path string, onReload func(*Decoder), options ...Option) error {
+	w := &Watcher{Path: path, Options: options, OnReload: onReload}
+	return w.Run(ctx)
+}
+
+// Run loads the catalog and watches it for changes until ctx is done. It
+// returns an error if the catalog cannot be loaded initially.
+func (w *Watcher) Run(ctx context.Context) error {
+	fi, err := w.load()
+	if err != nil {
+		return err
+	}
+	return pollFile(ctx, w.Path, w.Interval, fi, w.load, w.fail)
+}
+
+// pollFile calls load every time the file at path changes, until ctx is done.
+// fi describes the file as last loaded.
+func pollFile(ctx context.Context, path string, interval time.Duration, fi os.FileInfo, load func() (os.FileInfo, error), fail func(error)) error {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		latest, err := os.Stat(path)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		if latest.ModTime().Equal(fi.ModTime()) && latest.Size() == fi.Size() {
+			continue
+		}
+		if loaded, err := load(); err != nil {
+			fail(err)
+			fi = latest // do not retry until the file changes again
+		} else {
+			fi = loaded
+		}
+	}
+}
+
+func (w *Watcher) load() (os.FileInfo, error) {
+	fi, err := os.Stat(w.Path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := loadFile(os.DirFS(filepath.Dir(w.Path)), filepath.Base(w.Path))
+	if err != nil {
+		return nil, err
+	}
+	if w.OnReload != nil {
+		w.OnReload(New(rules, w.Options...))
+	}
+	return fi, nil
+}
+
+func (w *Watcher) fail(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
+
+// LocaleWatcher reloads the catalog of a locale from a file whenever it
+// changes, see Decoder.SetLocale, so translation fixes ship without
+// redeploying and independently of the rules.
+//
+// Changes are detected by polling the modification time and size of the file.
+// The format of the file is chosen by its extension, see ReadLocale.
+type LocaleWatcher struct {
+	// Decoder is the decoder whose catalog is reloaded.
+	Decoder *Decoder
+
+	// Locale is the locale of the catalog.
+	Locale string
+
+	// Path is the catalog file to watch.
+	Path string
+
+	// Interval is the polling interval. It defaults to one second.
+	Interval time.Duration
+
+	// OnError receives errors encountered while reloading the catalog, if
+	// set. The last good catalog remains in use until the file is fixed.
+	OnError func(error)
+}
+
+// Run loads the catalog and watches it for changes until ctx is done. It
+// returns an error if the catalog cannot be loaded initially.
+func (w *LocaleWatcher) Run(ctx context.Context) error {
+	fi, err := w.load()
+	if err != nil {
+		return err
+	}
+	return pollFile(ctx, w.Path, w.Interval, fi, w.load, w.fail)
+}
+
+func (w *LocaleWatcher) load() (os.FileInfo, error) {
+	f, err := os.Open(w.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := ReadLocale(f, filepath.Ext(w.Path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", w.Path, err)
+	}
+	w.Decoder.SetLocale(w.Locale, msgs)
+	return fi, nil
+}
+
+func (w *LocaleWatcher) fail(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
@@ -0,0 +1,154 @@
+package errdecode_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iamrgon/errdecode"
+)
+
+func TestWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.json")
+	write := func(msg string, mtime time.Time) {
+		t.Helper()
+		data := `{"rules": [{"code": 1001, "message": "` + msg + `", "errors": ["catalog.invalid_token"]}]}`
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	write("error.auth", time.Now().Add(-time.Hour))
+
+	reloads := make(chan *errdecode.Decoder, 1)
+	failures := make(chan error, 1)
+	w := &errdecode.Watcher{
+		Path:     path,
+		Interval: 5 * time.Millisecond,
+		OnReload: func(d *errdecode.Decoder) { reloads <- d },
+		OnError:  func(err error) { failures <- err },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	next := func() *errdecode.Decoder {
+		t.Helper()
+		select {
+		case d := <-reloads:
+			return d
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for reload")
+			return nil
+		}
+	}
+
+	if msg := next().Translate(errCatalogToken).Error(); msg != "error.auth" {
+		t.Fatalf("unexpected initial message: got='%s'", msg)
+	}
+
+	write("error.auth_v2", time.Now())
+	if msg := next().Translate(errCatalogToken).Error(); msg != "error.auth_v2" {
+		t.Fatalf("unexpected reloaded message: got='%s'", msg)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-failures:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for reload failure")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("unexpected error: got=%v want=%v", err, context.Canceled)
+	}
+}
+
+func TestWatchMissingFile(t *testing.T) {
+	err := errdecode.Watch(context.Background(), filepath.Join(t.TempDir(), "missing.yaml"), func(*errdecode.Decoder) {})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestLocaleWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fr.json")
+	write := func(data string, mtime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	write(`{"error.auth": "Jeton invalide."}`, time.Now().Add(-time.Hour))
+
+	dec := errdecode.New([]errdecode.Rule{{Code: 1001, Message: "error.auth", Errors: []error{errCatalogToken}}},
+		errdecode.LocaleMessages("fr", nil),
+	)
+	failures := make(chan error, 1)
+	w := &errdecode.LocaleWatcher{
+		Decoder:  dec,
+		Locale:   "fr",
+		Path:     path,
+		Interval: 5 * time.Millisecond,
+		OnError:  func(err error) { failures <- err },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	frCtx := errdecode.WithLocale(context.Background(), "fr")
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			msg := dec.TranslateContext(frCtx, errCatalogToken).Error()
+			if msg == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("unexpected message: got=%s want=%s", msg, want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitFor("Jeton invalide.")
+	write(`{"error.auth": "Jeton d'accès invalide."}`, time.Now())
+	waitFor("Jeton d'accès invalide.")
+
+	write(`{"error.auth": `, time.Now().Add(time.Minute))
+	select {
+	case <-failures:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for reload failure")
+	}
+	waitFor("Jeton d'accès invalide.")
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("unexpected error: got=%v want=%v", err, context.Canceled)
+	}
+}
+
+func TestLocaleWatcherUnsupportedFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fr.yaml")
+	if err := os.WriteFile(path, []byte("error.auth: Jeton invalide."), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w := &errdecode.LocaleWatcher{Decoder: errdecode.New(nil), Locale: "fr", Path: path}
+	if err := w.Run(context.Background()); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
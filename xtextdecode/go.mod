@@ -0,0 +1,13 @@
+module github.com/iamrgon/errdecode/xtextdecode
+
+go 1.21
+
+require (
+	github.com/iamrgon/errdecode v0.1.0
+	golang.org/x/text v0.19.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,57 @@
+// Package xtextdecode translates the messages of an errdecode.Decoder with
+// golang.org/x/text/message, using the message of rules as keys:
+//
+//	catalog.SetString(language.French, "error.not_found", "Commande {id} introuvable.")
+//
+//	decoder := errdecode.New(rules, xtextdecode.Catalog(catalog.DefaultCatalog, language.English))
+//	err = decoder.TranslateContext(errdecode.WithLocale(ctx, "fr-FR"), err)
+//
+// Keys missing from a catalog are used as messages. Since translated
+// messages are fmt formats, literal percent signs must be escaped as "%%",
+// while "{name}" placeholders are expanded by the decoder, see
+// errdecode.Fielder.
+package xtextdecode
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iamrgon/errdecode"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// Printer is used to translate messages with p, whatever the locale of
+// requests.
+func Printer(p *message.Printer) errdecode.Option {
+	return errdecode.Message(func(msg string) string {
+		return p.Sprintf(msg)
+	})
+}
+
+// Catalog is used to translate messages with c, to the locale attached to
+// the context passed to TranslateContext, see errdecode.WithLocale. The
+// locale is matched against the languages of c, falling back to the given
+// language for requests without a locale or with an unsupported one.
+func Catalog(c catalog.Catalog, fallback language.Tag) errdecode.Option {
+	tags := append([]language.Tag{fallback}, c.Languages()...)
+	matcher := language.NewMatcher(tags)
+	var printers sync.Map // locale → *message.Printer
+
+	return errdecode.MessageContext(func(ctx context.Context, msg string) string {
+		locale, _ := errdecode.LocaleFromContext(ctx)
+		p, ok := printers.Load(locale)
+		if !ok {
+			tag := fallback
+			if locale != "" {
+				_, i, confidence := matcher.Match(language.Make(locale))
+				if confidence != language.No {
+					tag = tags[i]
+				}
+			}
+			p, _ = printers.LoadOrStore(locale, message.NewPrinter(tag, message.Catalog(c)))
+		}
+		return p.(*message.Printer).Sprintf(msg)
+	})
+}
@@ -0,0 +1,74 @@
+package xtextdecode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/xtextdecode"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+var (
+	errNotFound = errors.New("order not found")
+	errDenied   = errors.New("access denied")
+)
+
+var rules = []errdecode.Rule{
+	{Code: 1001, Message: "error.not_found", Errors: []error{errNotFound}},
+	{Code: 1002, Message: "Access denied.", Errors: []error{errDenied}},
+}
+
+func newCatalog(t *testing.T) catalog.Catalog {
+	t.Helper()
+	b := catalog.NewBuilder()
+	for _, m := range []struct {
+		tag      language.Tag
+		key, msg string
+	}{
+		{language.English, "error.not_found", "Order not found."},
+		{language.French, "error.not_found", "Commande introuvable."},
+		{language.French, "Access denied.", "Accès refusé."},
+	} {
+		if err := b.SetString(m.tag, m.key, m.msg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return b
+}
+
+func TestCatalog(t *testing.T) {
+	dec := errdecode.New(rules, xtextdecode.Catalog(newCatalog(t), language.English))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want string
+	}{
+		{"no locale", context.Background(), errNotFound, "Order not found."},
+		{"locale", errdecode.WithLocale(context.Background(), "fr"), errNotFound, "Commande introuvable."},
+		{"regional locale", errdecode.WithLocale(context.Background(), "fr-CA"), errDenied, "Accès refusé."},
+		{"unsupported locale", errdecode.WithLocale(context.Background(), "ja-JP"), errNotFound, "Order not found."},
+		{"missing key", context.Background(), errDenied, "Access denied."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dec.TranslateContext(tt.ctx, tt.err).Error(); got != tt.want {
+				t.Fatalf("unexpected message: got=%s want=%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	p := message.NewPrinter(language.French, message.Catalog(newCatalog(t)))
+	dec := errdecode.New(rules, xtextdecode.Printer(p))
+	if got, want := dec.Translate(errNotFound).Error(), "Commande introuvable."; got != want {
+		t.Fatalf("unexpected message: got=%s want=%s", got, want)
+	}
+}
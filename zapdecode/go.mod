@@ -0,0 +1,14 @@
+module github.com/iamrgon/errdecode/zapdecode
+
+go 1.21
+
+require (
+	github.com/iamrgon/errdecode v0.1.0
+	go.uber.org/zap v1.27.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.6.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,65 @@
+// Package zapdecode expands the errors classified by an errdecode.Decoder
+// into go.uber.org/zap fields:
+//
+//	err = decoder.Translate(err)
+//	logger.Error("request failed", zapdecode.Fields(err)...)
+//
+// The fields mirror the slog representation of classified errors, see
+// errdecode.ClassifiedError.
+package zapdecode
+
+import (
+	"errors"
+
+	"github.com/iamrgon/errdecode"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Fields returns the fields of a classified error: its code, translated
+// message, message key, severity if any, whether it is retryable and its
+// cause. Unclassified errors are returned as a single zap.Error field, and
+// nil errors as no field.
+func Fields(err error) []zap.Field {
+	if err == nil {
+		return nil
+	}
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) {
+		return []zap.Field{zap.Error(err)}
+	}
+	fields := []zap.Field{
+		zap.Int("code", ce.Code()),
+		zap.String("message", ce.Error()),
+		zap.String("message_key", ce.MessageKey()),
+	}
+	if ce.Severity() != errdecode.SeverityUnspecified {
+		fields = append(fields, zap.Stringer("severity", ce.Severity()))
+	}
+	fields = append(fields, zap.Bool("retryable", ce.Retryable()))
+	if cause := ce.Unwrap(); cause != nil {
+		fields = append(fields, zap.String("cause", cause.Error()))
+	}
+	return fields
+}
+
+// Error is like zap.Error, but classified errors are logged as an object
+// holding their Fields under the "error" key.
+func Error(err error) zap.Field {
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) {
+		return zap.Error(err)
+	}
+	return zap.Object("error", object(Fields(err)))
+}
+
+// object marshals fields as a zap object.
+type object []zap.Field
+
+// MarshalLogObject satisfies the zapcore.ObjectMarshaler interface.
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range o {
+		f.AddTo(enc)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package zapdecode_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/zapdecode"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var (
+	errInvalid = errors.New("invalid email")
+	errUnknown = errors.New("boom")
+)
+
+var dec = errdecode.New([]errdecode.Rule{
+	{Code: 1001, Message: "error.invalid", Errors: []error{errInvalid}, Severity: errdecode.SeverityWarning},
+})
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want map[string]interface{}
+	}{
+		{"classified", fmt.Errorf("signup: %w", dec.Translate(errInvalid)), map[string]interface{}{
+			"code":        int64(1001),
+			"message":     "error.invalid",
+			"message_key": "error.invalid",
+			"severity":    "warning",
+			"retryable":   false,
+			"cause":       "invalid email",
+		}},
+		{"unclassified", dec.Translate(errUnknown), map[string]interface{}{
+			"error": "boom",
+		}},
+		{"nil", nil, map[string]interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			zap.New(core).Info("failed", zapdecode.Fields(tt.err)...)
+
+			got := logs.All()[0].ContextMap()
+			if len(got) != len(tt.want) {
+				t.Fatalf("unexpected fields: got=%v want=%v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Fatalf("unexpected field %s: got=%v want=%v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	zap.New(core).Info("failed", zapdecode.Error(dec.Translate(errInvalid)))
+
+	ctx := logs.All()[0].ContextMap()
+	obj, ok := ctx["error"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected error field: got=%#v", ctx["error"])
+	}
+	if obj["code"] != int64(1001) {
+		t.Fatalf("unexpected code: got=%v want=%d", obj["code"], 1001)
+	}
+
+	field := zapdecode.Error(errUnknown)
+	if want := zap.Error(errUnknown); !field.Equals(want) {
+		t.Fatalf("unexpected field: got=%v want=%v", field, want)
+	}
+}
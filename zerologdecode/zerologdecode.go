@@ -0,0 +1,71 @@
+// Package zerologdecode expands the errors classified by an
+// errdecode.Decoder into github.com/rs/zerolog fields:
+//
+//	err = decoder.Translate(err)
+//	log.Error().Object("error", zerologdecode.Error(err)).Msg("request failed")
+//
+// Alternatively, setting zerolog.ErrorMarshalFunc to MarshalError makes
+// Event.Err log classified errors as objects.
+package zerologdecode
+
+import (
+	"errors"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/rs/zerolog"
+)
+
+// Compile-time check.
+var _ zerolog.LogObjectMarshaler = classifiedError{}
+
+// classifiedError marshals a classified error as a zerolog object.
+type classifiedError struct {
+	ce errdecode.ClassifiedError
+}
+
+// Error returns a marshaler of the fields of a classified error: its code,
+// translated message, message key, severity if any, whether it is retryable
+// and its cause. Unclassified errors are marshaled with their message only.
+func Error(err error) zerolog.LogObjectMarshaler {
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) {
+		return unclassifiedError{err}
+	}
+	return classifiedError{ce}
+}
+
+// MarshalZerologObject satisfies the zerolog.LogObjectMarshaler interface.
+func (e classifiedError) MarshalZerologObject(ev *zerolog.Event) {
+	ev.Int("code", e.ce.Code()).
+		Str("message", e.ce.Error()).
+		Str("message_key", e.ce.MessageKey())
+	if e.ce.Severity() != errdecode.SeverityUnspecified {
+		ev.Stringer("severity", e.ce.Severity())
+	}
+	ev.Bool("retryable", e.ce.Retryable())
+	if cause := e.ce.Unwrap(); cause != nil {
+		ev.Str("cause", cause.Error())
+	}
+}
+
+// unclassifiedError marshals an unclassified error as a zerolog object.
+type unclassifiedError struct {
+	err error
+}
+
+// MarshalZerologObject satisfies the zerolog.LogObjectMarshaler interface.
+func (e unclassifiedError) MarshalZerologObject(ev *zerolog.Event) {
+	if e.err != nil {
+		ev.Str("message", e.err.Error())
+	}
+}
+
+// MarshalError is a zerolog.ErrorMarshalFunc marshaling classified errors
+// with Error. Other errors are returned as-is, as by the default function.
+func MarshalError(err error) interface{} {
+	var ce errdecode.ClassifiedError
+	if !errors.As(err, &ce) {
+		return err
+	}
+	return classifiedError{ce}
+}
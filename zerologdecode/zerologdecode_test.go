@@ -0,0 +1,89 @@
+package zerologdecode_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iamrgon/errdecode"
+	"github.com/iamrgon/errdecode/zerologdecode"
+	"github.com/rs/zerolog"
+)
+
+var (
+	errInvalid = errors.New("invalid email")
+	errUnknown = errors.New("boom")
+)
+
+var dec = errdecode.New([]errdecode.Rule{
+	{Code: 1001, Message: "error.invalid", Errors: []error{errInvalid}, Severity: errdecode.SeverityWarning},
+})
+
+var wantClassified = map[string]interface{}{
+	"code":        1001.0,
+	"message":     "error.invalid",
+	"message_key": "error.invalid",
+	"severity":    "warning",
+	"retryable":   false,
+	"cause":       "invalid email",
+}
+
+func decodeRecord(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return record
+}
+
+func checkObject(t *testing.T, got interface{}, want map[string]interface{}) {
+	t.Helper()
+	obj, ok := got.(map[string]interface{})
+	if !ok || len(obj) != len(want) {
+		t.Fatalf("unexpected object: got=%v want=%v", got, want)
+	}
+	for k, v := range want {
+		if obj[k] != v {
+			t.Fatalf("unexpected field %s: got=%v want=%v", k, obj[k], v)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want map[string]interface{}
+	}{
+		{"classified", fmt.Errorf("signup: %w", dec.Translate(errInvalid)), wantClassified},
+		{"unclassified", dec.Translate(errUnknown), map[string]interface{}{"message": "boom"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := zerolog.New(&buf)
+			logger.Error().Object("error", zerologdecode.Error(tt.err)).Msg("failed")
+			checkObject(t, decodeRecord(t, &buf)["error"], tt.want)
+		})
+	}
+}
+
+func TestMarshalError(t *testing.T) {
+	defer func(fn func(error) interface{}) { zerolog.ErrorMarshalFunc = fn }(zerolog.ErrorMarshalFunc)
+	zerolog.ErrorMarshalFunc = zerologdecode.MarshalError
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	logger.Error().Err(dec.Translate(errInvalid)).Msg("failed")
+	checkObject(t, decodeRecord(t, &buf)[zerolog.ErrorFieldName], wantClassified)
+
+	buf.Reset()
+	logger.Error().Err(errUnknown).Msg("failed")
+	if got := decodeRecord(t, &buf)[zerolog.ErrorFieldName]; got != "boom" {
+		t.Fatalf("unexpected error: got=%v want=%s", got, "boom")
+	}
+}